@@ -25,11 +25,26 @@ import (
 type IssuerSpec struct {
 	// URL is one or more base URLs for the CFSSL API, for example:
 	// "https://sample-signer.example.com/api,https//cfssl.example.com".
-	// If multiple comma seperated URLs are given and the first server cannot be reached,
-	// the next is used. The client will proceed in this manner until the list of
-	// servers is exhausted, and then an error is returned.
+	// If multiple comma seperated URLs are given, they are tried in the order
+	// given by Strategy. If a server cannot be reached, the next is used. The
+	// client will proceed in this manner until the list of servers is exhausted,
+	// and then an error is returned.
 	URL string `json:"url"`
 
+	// Strategy used to pick the order in which the servers given in URL are tried,
+	// one of ('OrderedList', 'RoundRobin', 'LowestLatency').
+	// "OrderedList" always starts with the first server, the others are only used
+	// for failover. "RoundRobin" starts with the next server on every request.
+	// "LowestLatency" starts with the server with the lowest average latency.
+	// If omitted, "OrderedList" is used.
+	// +optional
+	Strategy URLStrategy `json:"strategy,omitempty"`
+
+	// Configuration of the circuit breakers which take servers given in URL out
+	// of rotation after repeated failures.
+	// +optional
+	CircuitBreaker *CircuitBreakerConfig `json:"circuitBreaker,omitempty"`
+
 	// A reference to a Secret in the same namespace as the referent. If the
 	// referent is a ClusterIssuer, the reference instead refers to the resource
 	// with the given name in the configured 'cluster resource namespace', which
@@ -37,6 +52,8 @@ type IssuerSpec struct {
 	// namespace that the controller runs in).
 	// The secret needs to contain a field "key" containing the hex string used to
 	// authenticate against cfssl API as well as an optional "additional_data" field.
+	// To rotate the key, the new one can be added in an optional "key.next" field.
+	// Requests whose token is rejected by the cfssl API are retried with it.
 	AuthSecretName string `json:"authSecretName"`
 
 	// A string specifying which CFSSL signer to be appointed to sign the CSR.
@@ -44,22 +61,431 @@ type IssuerSpec struct {
 	// health checking the API) requires it to be set.
 	Label string `json:"label"`
 
+	// Labels of CFSSL signers tried in order if signing with Label fails
+	// because of the signer, for example because it is misconfigured or its
+	// key is unavailable. Requests rejected by the CFSSL API, for example
+	// because of its policy, are not retried with another label.
+	// +optional
+	FallbackLabels []string `json:"fallbackLabels,omitempty"`
+
+	// Gradual migration of the Certificates of the Issuer from Label to
+	// another signer label.
+	// +optional
+	Rollout *LabelRollout `json:"rollout,omitempty"`
+
 	// A string specifying the signing profile for the CFSSL signer (a signer may have
 	// multiple different profiles configured).
 	// If omitted, the "default" profile is used.
+	// This is the default for CertificateRequests not requesting a profile.
 	Profile string `json:"profile,omitempty"`
 
+	// Additional profiles CertificateRequests may request via the
+	// "cfssl-issuer.wikimedia.org/profile" annotation. CertificateRequests
+	// requesting a profile that is neither Profile nor one of these are failed.
+	// +optional
+	AllowedProfiles []string `json:"allowedProfiles,omitempty"`
+
+	// Rules selecting the profile of CertificateRequests which do not request
+	// one via annotation, based on their isCA and usages fields. The first
+	// matching selector is used. CertificateRequests no selector matches are
+	// failed. If omitted, Profile is used for all CertificateRequests.
+	// +optional
+	ProfileSelectors []ProfileSelector `json:"profileSelectors,omitempty"`
+
 	// A boolean specifying whether to include an "optimal" certificate bundle instead
 	// of the certificate.
 	Bundle bool `json:"bundle,omitempty"`
+
+	// A PEM encoded bundle of CA certificates used to verify the TLS certificate
+	// presented by the CFSSL API.
+	// If neither CABundle nor CABundleRef is set, the system certificate pool is used.
+	// If both are set, the certificates from both are trusted.
+	// +optional
+	CABundle []byte `json:"caBundle,omitempty"`
+
+	// A reference to a key in a Secret or ConfigMap containing a PEM encoded bundle
+	// of CA certificates used to verify the TLS certificate presented by the CFSSL API.
+	// The referenced resource is looked up in the same namespace as the one
+	// referenced by AuthSecretName.
+	// +optional
+	CABundleRef *CABundleReference `json:"caBundleRef,omitempty"`
+
+	// A reference to a Secret of type "kubernetes.io/tls" containing a client
+	// certificate and key which are presented to the CFSSL API for mutual TLS.
+	// The Secret is looked up in the same namespace as the one referenced by
+	// AuthSecretName. It is read on every health check and every signing
+	// request, so a rotated Secret is picked up without restarting the
+	// controller.
+	// +optional
+	ClientCertificateSecretName string `json:"clientCertificateSecretName,omitempty"`
+
+	// Configuration of the periodic health checks against the CFSSL API.
+	// +optional
+	HealthCheck *HealthCheckConfig `json:"healthCheck,omitempty"`
+
+	// Configures how the CA of issued certificates (ca.crt in the resulting Secret)
+	// is populated if Bundle is false. If omitted, no CA is provided.
+	// +optional
+	CA *CAConfig `json:"ca,omitempty"`
+
+	// Timeout of a single request to the CFSSL API. If multiple URLs are given,
+	// the timeout applies to every one of them individually.
+	// If omitted, zero or negative, a timeout of 30 seconds is used.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// Maximum validity of issued certificates. The duration requested by a
+	// CertificateRequest is capped to it. If omitted, requested durations are
+	// passed to the CFSSL API as they are.
+	// CertificateRequests without a duration always get the expiry of the
+	// profile, which is not capped.
+	// +optional
+	MaxDuration *metav1.Duration `json:"maxDuration,omitempty"`
+
+	// Fingerprints the signer certificate returned by the CFSSL info endpoint is
+	// expected to match. If set, the Issuer is not ready and signing fails if the
+	// signer certificate of any label matches none of them, or if an issued
+	// certificate was not signed by the signer certificate.
+	// +optional
+	CAPins *CAPins `json:"caPins,omitempty"`
+
+	// A boolean specifying whether cert-manager Certificates referencing the
+	// Issuer are re-issued when a health check observes a change of the signer
+	// certificate, so that they chain up to the new one.
+	// +optional
+	ReissueOnCARotation bool `json:"reissueOnCARotation,omitempty"`
 }
 
+// CAPins holds fingerprints of the expected signer certificate. The signer
+// certificate has to match at least one of them. Multiple pins allow for
+// rotating the signer certificate.
+type CAPins struct {
+	// Hex encoded SHA-256 fingerprints of the DER encoded SubjectPublicKeyInfo
+	// of the signer certificate. Colons between bytes are allowed.
+	// +optional
+	SPKISHA256 []string `json:"spkiSHA256,omitempty"`
+
+	// Hex encoded SHA-256 fingerprints of the DER encoded signer certificate.
+	// Colons between bytes are allowed.
+	// +optional
+	CertificateSHA256 []string `json:"certificateSHA256,omitempty"`
+}
+
+// LabelRollout configures the share of Certificates signed with a secondary
+// label instead of the primary Label of an Issuer. Which label is used is
+// decided per Certificate, so all CertificateRequests of a Certificate are
+// signed with the same label as long as Weight is not changed.
+type LabelRollout struct {
+	// Label of the secondary CFSSL signer.
+	Label string `json:"label"`
+
+	// Percentage of Certificates signed with the secondary label.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	Weight int32 `json:"weight"`
+}
+
+// ProfileSelector selects a CFSSL profile for CertificateRequests.
+type ProfileSelector struct {
+	// Profile used for matching CertificateRequests.
+	Profile string `json:"profile"`
+
+	// If set, only CertificateRequests with the same value of isCA match.
+	// +optional
+	IsCA *bool `json:"isCA,omitempty"`
+
+	// Key usages provided by the profile, using the names of cert-manager
+	// (for example "server auth" or "client auth"). CertificateRequests match
+	// if all of their usages are listed. CertificateRequests without usages
+	// request "digital signature" and "key encipherment".
+	// If omitted, CertificateRequests match regardless of their usages.
+	// +optional
+	Usages []string `json:"usages,omitempty"`
+}
+
+// URLStrategy specifies the order in which the servers of an Issuer are tried.
+// +kubebuilder:validation:Enum=OrderedList;RoundRobin;LowestLatency
+type URLStrategy string
+
+const (
+	// URLStrategyOrderedList tries the servers in the order they are given.
+	URLStrategyOrderedList URLStrategy = "OrderedList"
+
+	// URLStrategyRoundRobin starts with the next server on every request.
+	URLStrategyRoundRobin URLStrategy = "RoundRobin"
+
+	// URLStrategyLowestLatency starts with the server with the lowest average latency.
+	URLStrategyLowestLatency URLStrategy = "LowestLatency"
+)
+
+// CircuitBreakerConfig configures the circuit breakers of the servers of an Issuer.
+// A server is taken out of rotation for CoolDown after FailureThreshold consecutive
+// requests failed because it could not be reached or failed internally. Afterwards
+// it is tried again. If all servers are out of rotation, all of them are tried.
+type CircuitBreakerConfig struct {
+	// Number of consecutive failed requests after which a server is taken out of
+	// rotation. If omitted, 3 is used.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	FailureThreshold int32 `json:"failureThreshold,omitempty"`
+
+	// Duration a server is taken out of rotation for. If omitted, zero or
+	// negative, 30 seconds are used.
+	// +optional
+	CoolDown *metav1.Duration `json:"coolDown,omitempty"`
+}
+
+// CAConfig configures the CA provided along with issued certificates.
+type CAConfig struct {
+	// Source of the CA, one of ('Signer', 'TrustAnchor').
+	// "Signer" provides the signer certificate returned by the CFSSL info endpoint.
+	// "TrustAnchor" provides the chain from the signer certificate up to one of
+	// the certificates in TrustAnchors.
+	Source CASource `json:"source"`
+
+	// A PEM encoded bundle of trust anchors the signer certificate is expected
+	// to chain up to. Required if Source is "TrustAnchor".
+	// +optional
+	TrustAnchors []byte `json:"trustAnchors,omitempty"`
+}
+
+// CASource specifies where the CA of issued certificates is taken from.
+// +kubebuilder:validation:Enum=Signer;TrustAnchor
+type CASource string
+
+const (
+	// CASourceSigner provides the signer certificate as CA.
+	CASourceSigner CASource = "Signer"
+
+	// CASourceTrustAnchor provides the chain from the signer certificate up to
+	// a configured trust anchor as CA.
+	CASourceTrustAnchor CASource = "TrustAnchor"
+)
+
+// HealthCheckConfig configures the health checks of an Issuer.
+type HealthCheckConfig struct {
+	// A boolean specifying whether the health check should additionally query
+	// the authenticated /api/v1/cfssl/authinfo endpoint. This verifies the key
+	// from the auth Secret, which the unauthenticated info endpoint does not.
+	// The CFSSL API has to provide the authinfo endpoint for this to succeed.
+	// +optional
+	Authenticated bool `json:"authenticated,omitempty"`
+
+	// Window before the expiry of the signer certificate in which the
+	// CAExpiringSoon condition is set. If omitted, 30 days are used.
+	// +optional
+	CAExpiryWarning *metav1.Duration `json:"caExpiryWarning,omitempty"`
+
+	// Interval between health checks. If omitted, 1 minute is used.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// Number of consecutive failed health checks after which a ready Issuer
+	// is no longer considered ready. If omitted, 1 is used.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	FailureThreshold int32 `json:"failureThreshold,omitempty"`
+
+	// Number of consecutive successful health checks after which an Issuer
+	// which is not ready is considered ready again. If omitted, 1 is used.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	SuccessThreshold int32 `json:"successThreshold,omitempty"`
+}
+
+// HealthCheckStatus describes the outcome of the recent health checks of an
+// Issuer.
+type HealthCheckStatus struct {
+	// Time of the last health check.
+	LastCheckTime metav1.Time `json:"lastCheckTime"`
+
+	// Number of consecutive successful health checks up to the last one.
+	// +optional
+	ConsecutiveSuccesses int32 `json:"consecutiveSuccesses,omitempty"`
+
+	// Number of consecutive failed health checks up to the last one.
+	// +optional
+	ConsecutiveFailures int32 `json:"consecutiveFailures,omitempty"`
+}
+
+// CABundleReference is a reference to a key in a Secret or ConfigMap.
+type CABundleReference struct {
+	// Kind of the referenced resource, one of ('Secret', 'ConfigMap').
+	// +kubebuilder:validation:Enum=Secret;ConfigMap
+	Kind string `json:"kind"`
+
+	// Name of the referenced resource.
+	Name string `json:"name"`
+
+	// Key within the referenced resource containing the CA bundle.
+	// If omitted, "ca.crt" is used.
+	// +optional
+	Key string `json:"key,omitempty"`
+}
+
+const (
+	// CABundleReferenceKindSecret refers to a Secret containing a CA bundle.
+	CABundleReferenceKindSecret = "Secret"
+
+	// CABundleReferenceKindConfigMap refers to a ConfigMap containing a CA bundle.
+	CABundleReferenceKindConfigMap = "ConfigMap"
+
+	// DefaultCABundleKey is used if CABundleReference.Key is omitted.
+	DefaultCABundleKey = "ca.crt"
+)
+
 // IssuerStatus defines the observed state of Issuer
 type IssuerStatus struct {
-	// List of status conditions to indicate the status of a CertificateRequest.
-	// Known condition types are `Ready`.
+	// List of status conditions to indicate the status of an Issuer.
+	// Known condition types are `Ready`, `Degraded`, `AuthVerified` and
+	// `CAExpiringSoon`.
+	// +listType=map
+	// +listMapKey=type
+	// +patchStrategy=merge
+	// +patchMergeKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+
+	// Generation of the Issuer the status was last updated for.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Health of every server given in URL, as observed by the last health check.
+	// +optional
+	Endpoints []EndpointStatus `json:"endpoints,omitempty"`
+
+	// Health of Label and every one of FallbackLabels, as observed by the last
+	// health check.
+	// +optional
+	Labels []LabelStatus `json:"labels,omitempty"`
+
+	// Signer certificate and profile returned by the CFSSL info endpoint for
+	// the primary label, as observed by the last successful health check.
+	// +optional
+	CA *CAStatus `json:"ca,omitempty"`
+
+	// Number of CertificateRequests issued with the labels of Rollout since it
+	// was last changed.
+	// +optional
+	Rollout *RolloutStatus `json:"rollout,omitempty"`
+
+	// Last change of the signer certificate observed by a health check.
+	// +optional
+	LastCARotation *CARotation `json:"lastCARotation,omitempty"`
+
+	// Field of the auth Secret ("key" or "key.next") containing the key
+	// last accepted by the CFSSL API, by a signing request or an authenticated
+	// health check. It is updated by the health checks, and empty until the
+	// first authenticated request succeeded.
+	// +optional
+	AcceptedKey string `json:"acceptedKey,omitempty"`
+
+	// Time the key was last accepted by an authenticated health check.
 	// +optional
-	Conditions []IssuerCondition `json:"conditions,omitempty"`
+	LastAuthVerifiedTime *metav1.Time `json:"lastAuthVerifiedTime,omitempty"`
+
+	// Time and streak of the health checks.
+	// +optional
+	HealthCheck *HealthCheckStatus `json:"healthCheck,omitempty"`
+}
+
+// RolloutStatus counts the CertificateRequests issued with the primary and
+// the secondary label of a LabelRollout.
+type RolloutStatus struct {
+	// Secondary label of the rollout the counts refer to.
+	Label string `json:"label"`
+
+	// Weight of the rollout the counts refer to.
+	Weight int32 `json:"weight"`
+
+	// Number of CertificateRequests issued with the primary label or one of
+	// the FallbackLabels.
+	PrimaryRequests int64 `json:"primaryRequests"`
+
+	// Number of CertificateRequests issued with the secondary label.
+	SecondaryRequests int64 `json:"secondaryRequests"`
+}
+
+// CARotation describes a change of the signer certificate of an Issuer.
+type CARotation struct {
+	// Time the change was observed.
+	Time metav1.Time `json:"time"`
+
+	// Hex encoded SHA-256 fingerprint of the previous signer certificate.
+	PreviousSHA256Fingerprint string `json:"previousSHA256Fingerprint"`
+
+	// Hex encoded SHA-256 fingerprint of the new signer certificate.
+	SHA256Fingerprint string `json:"sha256Fingerprint"`
+}
+
+// LabelStatus is the observed state of the CFSSL signer of a single label.
+type LabelStatus struct {
+	// Label of the signer.
+	Label string `json:"label"`
+
+	// Whether the signer passed the last health check.
+	Healthy bool `json:"healthy"`
+
+	// Error of the last health check of the signer.
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+
+	// Signer certificate and profile returned by the CFSSL info endpoint for
+	// the label if it is healthy. Not set for the primary label, whose signer
+	// certificate is in the ca field of the status.
+	// +optional
+	CA *CAStatus `json:"ca,omitempty"`
+}
+
+// CAStatus holds details of the signer certificate and profile of an Issuer.
+type CAStatus struct {
+	// Subject of the signer certificate.
+	Subject string `json:"subject"`
+
+	// Serial number of the signer certificate, in decimal.
+	SerialNumber string `json:"serialNumber"`
+
+	// Hex encoded SHA-256 fingerprint of the DER encoded signer certificate.
+	SHA256Fingerprint string `json:"sha256Fingerprint"`
+
+	// Start of the validity of the signer certificate.
+	NotBefore metav1.Time `json:"notBefore"`
+
+	// End of the validity of the signer certificate.
+	NotAfter metav1.Time `json:"notAfter"`
+
+	// Usages of the profile, as named by CFSSL.
+	// +optional
+	Usages []string `json:"usages,omitempty"`
+
+	// Expiry of certificates issued with the profile, for example "8760h".
+	// +optional
+	Expiry string `json:"expiry,omitempty"`
+}
+
+// EndpointStatus is the observed state of a single CFSSL API server.
+type EndpointStatus struct {
+	// URL of the server.
+	URL string `json:"url"`
+
+	// Whether the server could be reached by the last health check.
+	Healthy bool `json:"healthy"`
+
+	// Whether the server is currently taken out of rotation by its circuit breaker.
+	// +optional
+	CircuitOpen bool `json:"circuitOpen,omitempty"`
+
+	// Number of consecutive failed requests to the server.
+	// +optional
+	ConsecutiveFailures int32 `json:"consecutiveFailures,omitempty"`
+
+	// Average latency of successful requests to the server.
+	// +optional
+	Latency *metav1.Duration `json:"latency,omitempty"`
+
+	// Error of the last failed request to the server.
+	// +optional
+	LastError string `json:"lastError,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -83,59 +509,144 @@ type IssuerList struct {
 	Items           []Issuer `json:"items"`
 }
 
-// IssuerCondition contains condition information for an Issuer.
-type IssuerCondition struct {
-	// Type of the condition, known values are ('Ready').
-	Type IssuerConditionType `json:"type"`
-
-	// Status of the condition, one of ('True', 'False', 'Unknown').
-	Status ConditionStatus `json:"status"`
-
-	// LastTransitionTime is the timestamp corresponding to the last status
-	// change of this condition.
-	// +optional
-	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
-
-	// Reason is a brief machine readable explanation for the condition's last
-	// transition.
-	// +optional
-	Reason string `json:"reason,omitempty"`
-
-	// Message is a human readable description of the details of the last
-	// transition, complementing reason.
-	// +optional
-	Message string `json:"message,omitempty"`
-}
-
-// IssuerConditionType represents an Issuer condition value.
-type IssuerConditionType string
-
+// Known types of the conditions of an Issuer.
 const (
 	// IssuerConditionReady represents the fact that a given Issuer condition
 	// is in ready state and able to issue certificates.
 	// If the `status` of this condition is `False`, CertificateRequest controllers
 	// should prevent attempts to sign certificates.
-	IssuerConditionReady IssuerConditionType = "Ready"
+	IssuerConditionReady = "Ready"
+
+	// IssuerConditionDegraded is True if some of the servers given in URL or
+	// some of the labels of an Issuer are failing, while it is still able to
+	// issue certificates with the others.
+	IssuerConditionDegraded = "Degraded"
+
+	// IssuerConditionAuthVerified is True if the key from the auth Secret was
+	// accepted by the last authenticated health check, and False if it was
+	// rejected. It is only set if HealthCheckConfig.Authenticated is.
+	IssuerConditionAuthVerified = "AuthVerified"
+
+	// IssuerConditionCAExpiringSoon is True if the signer certificate expires
+	// within the window configured by HealthCheckConfig.CAExpiryWarning.
+	IssuerConditionCAExpiringSoon = "CAExpiringSoon"
+
+	// IssuerConditionCertificatesReissued is False while the Certificates
+	// referencing an Issuer are being re-issued after a rotation of its signer
+	// certificate, and True once all of them were. It is only set if
+	// ReissueOnCARotation is.
+	IssuerConditionCertificatesReissued = "CertificatesReissued"
 )
 
-// ConditionStatus represents a condition's status.
-// +kubebuilder:validation:Enum=True;False;Unknown
-type ConditionStatus string
+// IssuerConditionReason is a machine readable explanation of the status of an
+// Issuer condition. The Events of an Issuer use the reason of the condition
+// they report.
+type IssuerConditionReason string
 
-// These are valid condition statuses. "ConditionTrue" means a resource is in
-// the condition; "ConditionFalse" means a resource is not in the condition;
-// "ConditionUnknown" means kubernetes can't decide if a resource is in the
-// condition or not. In the future, we could add other intermediate
-// conditions, e.g. ConditionDegraded.
 const (
-	// ConditionTrue represents the fact that a given condition is true
-	ConditionTrue ConditionStatus = "True"
+	// IssuerReasonUnspecified is used for conditions written without a reason
+	// before the status used metav1.Condition.
+	IssuerReasonUnspecified IssuerConditionReason = "Unspecified"
+
+	// IssuerReasonPending is used for the Ready condition of an Issuer which
+	// was not checked yet.
+	IssuerReasonPending IssuerConditionReason = "Pending"
+
+	// IssuerReasonHealthy is used for the Ready condition of an Issuer whose
+	// last health check succeeded.
+	IssuerReasonHealthy IssuerConditionReason = "Healthy"
+
+	// IssuerReasonFailureTolerated is used for the Ready condition of a ready
+	// Issuer whose last health checks failed fewer times in a row than
+	// HealthCheckConfig.FailureThreshold.
+	IssuerReasonFailureTolerated IssuerConditionReason = "FailureTolerated"
+
+	// IssuerReasonRecovering is used for the Ready condition of an Issuer which
+	// is not ready, whose last health checks succeeded fewer times in a row
+	// than HealthCheckConfig.SuccessThreshold.
+	IssuerReasonRecovering IssuerConditionReason = "Recovering"
+
+	// IssuerReasonAuthSecretUnavailable is used when the Secret referenced by
+	// AuthSecretName could not be read, most likely because it does not exist.
+	IssuerReasonAuthSecretUnavailable IssuerConditionReason = "AuthSecretUnavailable"
+
+	// IssuerReasonAuthSecretKeyMissing is used when the Secret referenced by
+	// AuthSecretName does not contain the "key" field.
+	IssuerReasonAuthSecretKeyMissing IssuerConditionReason = "AuthSecretKeyMissing"
+
+	// IssuerReasonInvalidAuthKey is used when a key in the Secret referenced
+	// by AuthSecretName is not a hex encoded string.
+	IssuerReasonInvalidAuthKey IssuerConditionReason = "InvalidAuthKey"
+
+	// IssuerReasonCABundleUnavailable is used when the CA bundle referenced by
+	// CABundleRef could not be read.
+	IssuerReasonCABundleUnavailable IssuerConditionReason = "CABundleUnavailable"
+
+	// IssuerReasonClientCertificateUnavailable is used when the Secret
+	// referenced by ClientCertificateSecretName could not be read.
+	IssuerReasonClientCertificateUnavailable IssuerConditionReason = "ClientCertificateUnavailable"
+
+	// IssuerReasonInvalidConfiguration is used when the spec of an Issuer is
+	// invalid in another way, for example because of a malformed URL or CA pin.
+	IssuerReasonInvalidConfiguration IssuerConditionReason = "InvalidConfiguration"
+
+	// IssuerReasonCFSSLUnavailable is used when none of the CFSSL API servers
+	// could be reached or all of them failed internally.
+	IssuerReasonCFSSLUnavailable IssuerConditionReason = "CFSSLUnavailable"
+
+	// IssuerReasonLabelRejected is used when the CFSSL API answered the info
+	// request for the labels of an Issuer with an error, for example because
+	// it does not know the label.
+	IssuerReasonLabelRejected IssuerConditionReason = "LabelRejected"
+
+	// IssuerReasonAuthenticationFailed is used when the CFSSL API rejected an
+	// authenticated health check, most likely because of a wrong key.
+	IssuerReasonAuthenticationFailed IssuerConditionReason = "AuthenticationFailed"
+
+	// IssuerReasonCAPinMismatch is used when the signer certificate of the
+	// CFSSL API does not match the CA pins of an Issuer.
+	IssuerReasonCAPinMismatch IssuerConditionReason = "CAPinMismatch"
+
+	// IssuerReasonHealthCheckFailed is used when a health check failed for
+	// another reason, for example because the signer certificate is invalid.
+	IssuerReasonHealthCheckFailed IssuerConditionReason = "HealthCheckFailed"
+
+	// IssuerReasonReissuePending is used for the CertificatesReissued
+	// condition while Certificates are left to re-issue.
+	IssuerReasonReissuePending IssuerConditionReason = "ReissuePending"
+
+	// IssuerReasonReissueFailed is used for the CertificatesReissued condition
+	// if some of the Certificates referencing an Issuer could not be
+	// re-issued. They are retried.
+	IssuerReasonReissueFailed IssuerConditionReason = "ReissueFailed"
+
+	// IssuerReasonReissued is used for the CertificatesReissued condition once
+	// all Certificates referencing an Issuer were re-issued.
+	IssuerReasonReissued IssuerConditionReason = "Reissued"
+
+	// IssuerReasonError is used for unexpected errors, like failures to talk
+	// to the Kubernetes API.
+	IssuerReasonError IssuerConditionReason = "Error"
+
+	// IssuerReasonEndpointsUnhealthy is used for the Degraded condition if
+	// some of the servers given in URL are failing.
+	IssuerReasonEndpointsUnhealthy IssuerConditionReason = "EndpointsUnhealthy"
+
+	// IssuerReasonLabelsUnhealthy is used for the Degraded condition if some
+	// of the labels are failing, while all servers are healthy.
+	IssuerReasonLabelsUnhealthy IssuerConditionReason = "LabelsUnhealthy"
+
+	// IssuerReasonKeyAccepted is used for the AuthVerified condition if the
+	// key was accepted by the CFSSL API.
+	IssuerReasonKeyAccepted IssuerConditionReason = "KeyAccepted"
 
-	// ConditionFalse represents the fact that a given condition is false
-	ConditionFalse ConditionStatus = "False"
+	// IssuerReasonCAValid is used for the CAExpiringSoon condition if the
+	// signer certificate does not expire within the warning window.
+	IssuerReasonCAValid IssuerConditionReason = "CAValid"
 
-	// ConditionUnknown represents the fact that a given condition is unknown
-	ConditionUnknown ConditionStatus = "Unknown"
+	// IssuerReasonCAExpiringSoon is used for the CAExpiringSoon condition if
+	// the signer certificate expires within the warning window.
+	IssuerReasonCAExpiringSoon IssuerConditionReason = "CAExpiringSoon"
 )
 
 func init() {
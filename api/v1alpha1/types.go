@@ -16,8 +16,30 @@ limitations under the License.
 
 package v1alpha1
 
+const (
+	// ProfileAnnotationKey is the annotation a CertificateRequest can use to
+	// request one of the AllowedProfiles of its {Cluster}Issuer.
+	ProfileAnnotationKey = "cfssl-issuer.wikimedia.org/profile"
+
+	// CARotatedAnnotationKey is the annotation set on cert-manager Certificates
+	// re-issued because the signer certificate of their {Cluster}Issuer changed.
+	// Its value is the SHA-256 fingerprint of the new signer certificate.
+	CARotatedAnnotationKey = "cfssl-issuer.wikimedia.org/ca-rotated"
+
+	// LabelAnnotationKey is the annotation set on CertificateRequests to record
+	// the label of the CFSSL signer which signed them.
+	LabelAnnotationKey = "cfssl-issuer.wikimedia.org/label"
+)
+
 const (
 	EventSource                             = "cfssl-issuer"
 	EventReasonCertificateRequestReconciler = "CertificateRequestReconciler"
-	EventReasonIssuerReconciler             = "IssuerReconciler"
+
+	// EventReasonDurationMismatch is used when the validity of an issued
+	// certificate differs from the one requested.
+	EventReasonDurationMismatch = "DurationMismatch"
+
+	// EventReasonCARotated is used when a health check observed a change of
+	// the signer certificate of an Issuer.
+	EventReasonCARotated = "CARotated"
 )
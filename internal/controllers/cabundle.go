@@ -0,0 +1,83 @@
+/*
+Copyright 2021 The Wikimedia Foundation, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cfsslissuerapi "gerrit.wikimedia.org/r/operations/software/cfssl-issuer/api/v1alpha1"
+)
+
+var (
+	errGetCABundle        = errors.New("failed to get CA bundle")
+	errCABundleKeyMissing = errors.New("CA bundle reference points to a missing key")
+)
+
+// getCABundle returns the PEM encoded CA bundle configured for an {Cluster}Issuer.
+// CABundleRef is resolved in the given namespace and appended to the inline CABundle.
+func getCABundle(ctx context.Context, c client.Client, issuerSpec *cfsslissuerapi.IssuerSpec, namespace string) ([]byte, error) {
+	caBundle := append([]byte{}, issuerSpec.CABundle...)
+
+	ref := issuerSpec.CABundleRef
+	if ref == nil {
+		return caBundle, nil
+	}
+
+	name := types.NamespacedName{
+		Name:      ref.Name,
+		Namespace: namespace,
+	}
+	key := ref.Key
+	if key == "" {
+		key = cfsslissuerapi.DefaultCABundleKey
+	}
+
+	var data []byte
+	var ok bool
+	switch ref.Kind {
+	case cfsslissuerapi.CABundleReferenceKindSecret:
+		var secret corev1.Secret
+		if err := c.Get(ctx, name, &secret); err != nil {
+			return nil, fmt.Errorf("%w, secret name: %s, reason: %v", errGetCABundle, name, err)
+		}
+		data, ok = secret.Data[key]
+	case cfsslissuerapi.CABundleReferenceKindConfigMap:
+		var configMap corev1.ConfigMap
+		if err := c.Get(ctx, name, &configMap); err != nil {
+			return nil, fmt.Errorf("%w, configmap name: %s, reason: %v", errGetCABundle, name, err)
+		}
+		var value string
+		value, ok = configMap.Data[key]
+		data = []byte(value)
+	default:
+		return nil, fmt.Errorf("%w, unsupported kind: %q", errGetCABundle, ref.Kind)
+	}
+	if !ok {
+		return nil, fmt.Errorf("%w, %s name: %s, key: %q", errCABundleKeyMissing, ref.Kind, name, key)
+	}
+
+	if len(caBundle) > 0 && caBundle[len(caBundle)-1] != '\n' {
+		caBundle = append(caBundle, '\n')
+	}
+	return append(caBundle, data...), nil
+}
@@ -19,8 +19,11 @@ package controllers
 
 import (
 	"context"
+	"crypto/x509"
+	"encoding/pem"
 	"errors"
 	"fmt"
+	"time"
 
 	cmutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
@@ -33,19 +36,35 @@ import (
 	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/clock"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	cfsslissuerapi "gerrit.wikimedia.org/r/operations/software/cfssl-issuer/api/v1alpha1"
 	"gerrit.wikimedia.org/r/operations/software/cfssl-issuer/internal/issuer/signer"
 	issuerutil "gerrit.wikimedia.org/r/operations/software/cfssl-issuer/internal/issuer/util"
 )
 
+const (
+	// Deviation from the requested duration tolerated for issued certificates.
+	durationTolerance = time.Minute
+
+	// Time CFSSL backdates the certificates it issues by default, which makes
+	// them valid for longer than the requested duration.
+	cfsslBackdate = 5 * time.Minute
+
+	// Field index of the CertificateRequests by the issuer they reference.
+	issuerRefField = "spec.issuerRef"
+)
+
 var (
-	errIssuerRef      = errors.New("error interpreting issuerRef")
-	errGetIssuer      = errors.New("error getting issuer")
-	errIssuerNotReady = errors.New("issuer is not ready")
-	errSignerBuilder  = errors.New("failed to build the signer")
-	errSignerSign     = errors.New("failed to sign")
+	errIssuerRef     = errors.New("error interpreting issuerRef")
+	errGetIssuer     = errors.New("error getting issuer")
+	errSignerBuilder = errors.New("failed to build the signer")
+	errSignerSign    = errors.New("failed to sign")
 )
 
 // CertificateRequestReconciler reconciles a CertificateRequest object
@@ -60,9 +79,10 @@ type CertificateRequestReconciler struct {
 	recorder               record.EventRecorder
 }
 
-// +kubebuilder:rbac:groups=cert-manager.io,resources=certificaterequests,verbs=get;list;watch
+// +kubebuilder:rbac:groups=cert-manager.io,resources=certificaterequests,verbs=get;list;watch;patch
 // +kubebuilder:rbac:groups=cert-manager.io,resources=certificaterequests/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 func (r *CertificateRequestReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
@@ -149,6 +169,9 @@ func (r *CertificateRequestReconciler) Reconcile(ctx context.Context, req ctrl.R
 		)
 	}
 
+	// Label of the signer which signed the issued certificate, if any
+	var signedLabel string
+
 	// Always attempt to update the Ready condition
 	defer func() {
 		if err != nil {
@@ -157,6 +180,15 @@ func (r *CertificateRequestReconciler) Reconcile(ctx context.Context, req ctrl.R
 		if updateErr := r.Status().Update(ctx, &certificateRequest); updateErr != nil {
 			err = utilerrors.NewAggregate([]error{err, updateErr})
 			result = ctrl.Result{}
+			return
+		}
+		// The Issuer may fall back to other labels, record which one signed.
+		// This is done once the certificate is in the status, so failing to
+		// record it does not get another certificate signed.
+		if signedLabel != "" {
+			if err := r.recordLabel(ctx, &certificateRequest, signedLabel); err != nil {
+				log.Error(err, "Failed to record the signer label")
+			}
 		}
 	}()
 
@@ -165,10 +197,7 @@ func (r *CertificateRequestReconciler) Reconcile(ctx context.Context, req ctrl.R
 	if cmutil.CertificateRequestIsDenied(&certificateRequest) {
 		log.Info("CertificateRequest has been denied yet. Marking as failed.")
 
-		if certificateRequest.Status.FailureTime == nil {
-			nowTime := metav1.NewTime(r.Clock.Now())
-			certificateRequest.Status.FailureTime = &nowTime
-		}
+		r.setFailureTime(&certificateRequest)
 
 		message := "The CertificateRequest was denied by an approval controller"
 		report(cmapi.CertificateRequestReasonDenied, message, nil)
@@ -181,6 +210,15 @@ func (r *CertificateRequestReconciler) Reconcile(ctx context.Context, req ctrl.R
 		return ctrl.Result{}, nil
 	}
 
+	// A malformed CSR will not get any better by retrying, so mark the
+	// CertificateRequest as failed right away.
+	csr, err := signer.ParseCSR(certificateRequest.Spec.Request)
+	if err != nil {
+		r.setFailureTime(&certificateRequest)
+		report(cmapi.CertificateRequestReasonFailed, "Invalid CSR", err)
+		return ctrl.Result{}, nil
+	}
+
 	// Ignore but log an error if the issuerRef.Kind is unrecognised
 	issuerGVK := cfsslissuerapi.GroupVersion.WithKind(certificateRequest.Spec.IssuerRef.Kind)
 	issuerRO, err := r.Scheme.New(issuerGVK)
@@ -218,8 +256,17 @@ func (r *CertificateRequestReconciler) Reconcile(ctx context.Context, req ctrl.R
 		return ctrl.Result{}, nil
 	}
 
+	// The CertificateRequest is reconciled again once the issuer becomes ready
 	if !issuerutil.IsReady(issuerStatus) {
-		return ctrl.Result{}, errIssuerNotReady
+		report(cmapi.CertificateRequestReasonPending, "Waiting for the issuer to become ready", nil)
+		return ctrl.Result{}, nil
+	}
+
+	profile, err := requestedProfile(&certificateRequest, issuerSpec)
+	if err != nil {
+		r.setFailureTime(&certificateRequest)
+		report(cmapi.CertificateRequestReasonFailed, "Unable to select a profile", err)
+		return ctrl.Result{}, nil
 	}
 
 	secretName := types.NamespacedName{
@@ -232,27 +279,199 @@ func (r *CertificateRequestReconciler) Reconcile(ctx context.Context, req ctrl.R
 		return ctrl.Result{}, fmt.Errorf("%w, secret name: %s, reason: %v", errGetAuthSecret, secretName, err)
 	}
 
-	signer, err := r.SignerBuilder(issuerSpec, secret.Data)
+	caBundle, err := getCABundle(ctx, r.Client, issuerSpec, secretNamespace)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	clientCertData, err := getClientCertificateSecretData(ctx, r.Client, issuerSpec, secretNamespace)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	crSigner, err := r.SignerBuilder(issuerSpec, &signer.IssuerData{
+		IssuerKey:                   issuerKey(certificateRequest.Spec.IssuerRef.Kind, issuerName),
+		AuthSecretData:              secret.Data,
+		CABundle:                    caBundle,
+		ClientCertificateSecretData: clientCertData,
+	})
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("%w: %v", errSignerBuilder, err)
 	}
 
-	ca, cert, err := signer.Sign(ctx, certificateRequest.Spec.Request)
+	duration, durationMessage := requestedDuration(&certificateRequest, issuerSpec)
+	signRequest := &signer.SignRequest{
+		CSR:      certificateRequest.Spec.Request,
+		Duration: duration,
+		Profile:  profile,
+		Label:    rolloutLabel(&certificateRequest, issuerSpec),
+	}
+	signStart := r.Clock.Now()
+	signResult, err := crSigner.Sign(ctx, signRequest)
+	observeSign(certificateRequest.Spec.IssuerRef.Kind, issuerName, issuerSpec, signRequest, signResult, r.Clock.Since(signStart), err)
+	// Retrying requests rejected by the CFSSL API is pointless, so mark the
+	// CertificateRequest as failed instead.
+	if errors.Is(err, signer.ErrRequestRejected) {
+		r.setFailureTime(&certificateRequest)
+		report(cmapi.CertificateRequestReasonFailed, "Permanent error", fmt.Errorf("%w: %v", errSignerSign, err))
+		return ctrl.Result{}, nil
+	}
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("%w: %v", errSignerSign, err)
 	}
+	ca, cert := signResult.CA, signResult.Certificate
+	// Don't hand out certificates which do not match the request
+	if err := verifyCertificate(&certificateRequest, csr, cert, ca, r.Clock.Now()); err != nil {
+		r.setFailureTime(&certificateRequest)
+		report(cmapi.CertificateRequestReasonFailed, "Verification of the issued certificate failed", err)
+		return ctrl.Result{}, nil
+	}
 	if len(ca) > 0 {
 		certificateRequest.Status.CA = ca
 	}
 	certificateRequest.Status.Certificate = cert
+	signedLabel = signResult.Label
+
+	if duration > 0 {
+		r.checkDuration(&certificateRequest, cert, duration, durationMessage)
+	}
+
+	// The certificate has been issued, failing to count it must not get it
+	// signed again.
+	if err := countRolloutRequest(ctx, r.Client, issuer, signResult.Label); err != nil {
+		log.Error(err, "Failed to update the rollout status of the issuer")
+	}
 
 	report(cmapi.CertificateRequestReasonIssued, "Signed", nil)
 	return ctrl.Result{}, nil
 }
 
+// recordLabel sets the annotation recording the label of the signer which
+// signed the CertificateRequest.
+func (r *CertificateRequestReconciler) recordLabel(ctx context.Context, certificateRequest *cmapi.CertificateRequest, label string) error {
+	if certificateRequest.Annotations[cfsslissuerapi.LabelAnnotationKey] == label {
+		return nil
+	}
+	patch := client.MergeFrom(certificateRequest.DeepCopy())
+	metav1.SetMetaDataAnnotation(&certificateRequest.ObjectMeta, cfsslissuerapi.LabelAnnotationKey, label)
+	return r.Patch(ctx, certificateRequest, patch)
+}
+
+// requestedDuration returns the validity to request for a CertificateRequest,
+// capped by the MaxDuration of the Issuer, along with a description of it.
+func requestedDuration(certificateRequest *cmapi.CertificateRequest, issuerSpec *cfsslissuerapi.IssuerSpec) (time.Duration, string) {
+	if certificateRequest.Spec.Duration == nil {
+		return 0, ""
+	}
+	duration := certificateRequest.Spec.Duration.Duration
+	if issuerSpec.MaxDuration != nil && duration > issuerSpec.MaxDuration.Duration {
+		return issuerSpec.MaxDuration.Duration, fmt.Sprintf("%s (capped from %s by the maxDuration of the issuer)", issuerSpec.MaxDuration.Duration, duration)
+	}
+	return duration, duration.String()
+}
+
+// checkDuration emits a Warning event if the validity of the PEM encoded
+// certificate differs from the requested duration by more than
+// durationTolerance, not counting the backdating by CFSSL.
+func (r *CertificateRequestReconciler) checkDuration(certificateRequest *cmapi.CertificateRequest, certPEM []byte, duration time.Duration, durationMessage string) {
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return
+	}
+	validity := cert.NotAfter.Sub(cert.NotBefore)
+	if diff := validity - duration; diff > cfsslBackdate+durationTolerance || diff < -durationTolerance {
+		r.recorder.Eventf(
+			certificateRequest,
+			corev1.EventTypeWarning,
+			cfsslissuerapi.EventReasonDurationMismatch,
+			"Issued certificate is valid for %s, but %s was requested",
+			validity,
+			durationMessage,
+		)
+	}
+}
+
+// setFailureTime sets the FailureTime of a CertificateRequest if not already set.
+func (r *CertificateRequestReconciler) setFailureTime(certificateRequest *cmapi.CertificateRequest) {
+	if certificateRequest.Status.FailureTime == nil {
+		nowTime := metav1.NewTime(r.Clock.Now())
+		certificateRequest.Status.FailureTime = &nowTime
+	}
+}
+
+// issuerRef is the IndexerFunc of issuerRefField. CertificateRequests are
+// indexed by the kind and name of the issuer they reference, if it is one of
+// ours.
+func issuerRef(obj client.Object) []string {
+	cr, ok := obj.(*cmapi.CertificateRequest)
+	if !ok || cr.Spec.IssuerRef.Group != cfsslissuerapi.GroupVersion.Group {
+		return nil
+	}
+	return []string{issuerRefValue(cr.Spec.IssuerRef.Kind, cr.Spec.IssuerRef.Name)}
+}
+
+func issuerRefValue(kind, name string) string {
+	return kind + "/" + name
+}
+
+// certificateRequestsForIssuer maps an Issuer or ClusterIssuer to the requests
+// of the CertificateRequests referencing it.
+func (r *CertificateRequestReconciler) certificateRequestsForIssuer(ctx context.Context, issuer client.Object) []reconcile.Request {
+	var opts []client.ListOption
+	switch issuer.(type) {
+	case *cfsslissuerapi.Issuer:
+		opts = append(opts,
+			client.InNamespace(issuer.GetNamespace()),
+			client.MatchingFields{issuerRefField: issuerRefValue("Issuer", issuer.GetName())},
+		)
+	case *cfsslissuerapi.ClusterIssuer:
+		opts = append(opts, client.MatchingFields{issuerRefField: issuerRefValue("ClusterIssuer", issuer.GetName())})
+	default:
+		return nil
+	}
+	var crs cmapi.CertificateRequestList
+	if err := r.List(ctx, &crs, opts...); err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "Failed to list CertificateRequests referencing issuer", "issuer", client.ObjectKeyFromObject(issuer))
+		return nil
+	}
+	var requests []reconcile.Request
+	for i := range crs.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&crs.Items[i])})
+	}
+	return requests
+}
+
+// issuerBecameReady only passes updates of an Issuer or ClusterIssuer whose
+// Ready condition changed to True.
+var issuerBecameReady = predicate.Funcs{
+	CreateFunc:  func(event.CreateEvent) bool { return false },
+	DeleteFunc:  func(event.DeleteEvent) bool { return false },
+	GenericFunc: func(event.GenericEvent) bool { return false },
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		_, oldStatus, err := issuerutil.GetSpecAndStatus(e.ObjectOld)
+		if err != nil {
+			return false
+		}
+		_, newStatus, err := issuerutil.GetSpecAndStatus(e.ObjectNew)
+		if err != nil {
+			return false
+		}
+		return !issuerutil.IsReady(oldStatus) && issuerutil.IsReady(newStatus)
+	},
+}
+
 func (r *CertificateRequestReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &cmapi.CertificateRequest{}, issuerRefField, issuerRef); err != nil {
+		return err
+	}
 	r.recorder = mgr.GetEventRecorderFor(cfsslissuerapi.EventSource)
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&cmapi.CertificateRequest{}).
+		Watches(&cfsslissuerapi.Issuer{}, handler.EnqueueRequestsFromMapFunc(r.certificateRequestsForIssuer), builder.WithPredicates(issuerBecameReady)).
+		Watches(&cfsslissuerapi.ClusterIssuer{}, handler.EnqueueRequestsFromMapFunc(r.certificateRequestsForIssuer), builder.WithPredicates(issuerBecameReady)).
 		Complete(r)
 }
@@ -2,14 +2,24 @@ package controllers
 
 import (
 	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
 	"errors"
 	"fmt"
+	"math/big"
 	"testing"
 	"time"
 
 	cmutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
 	cmgen "github.com/cert-manager/cert-manager/test/unit/gen"
 	logrtesting "github.com/go-logr/logr/testing"
 	"github.com/stretchr/testify/assert"
@@ -22,9 +32,12 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/record"
 	clock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/pointer"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	cfsslissuerapi "gerrit.wikimedia.org/r/operations/software/cfssl-issuer/api/v1alpha1"
@@ -32,16 +45,138 @@ import (
 )
 
 var (
-	fixedClockStart = time.Date(2021, time.January, 1, 1, 0, 0, 0, time.UTC)
-	fixedClock      = clock.NewFakeClock(fixedClockStart)
+	fixedClockStart      = time.Date(2021, time.January, 1, 1, 0, 0, 0, time.UTC)
+	fixedClock           = clock.NewFakeClock(fixedClockStart)
+	testCAKey, testCA    = mustGenerateCA()
+	_, otherCA           = mustGenerateCA()
+	validCSR             = mustGenerateCSR()
+	otherCSR             = mustGenerateCSR()
+	validCertificate     = mustSignCSR(validCSR, 24*time.Hour)
+	validCACertificate   = mustSignCSR(validCSR, 24*time.Hour, func(template *x509.Certificate) { template.IsCA = true })
+	certValidFor1h       = mustSignCSR(validCSR, time.Hour)
+	certValidFor24h      = mustSignCSR(validCSR, 24*time.Hour)
+	certBackdatedFor24h  = mustSignCSR(validCSR, 24*time.Hour, func(template *x509.Certificate) { template.NotBefore = template.NotBefore.Add(-cfsslBackdate) })
+	certForOtherCSR      = mustSignCSR(otherCSR, 24*time.Hour)
+	certWithExtraSAN     = mustSignCSR(validCSR, 24*time.Hour, func(template *x509.Certificate) { template.DNSNames = append(template.DNSNames, "evil.example.com") })
+	certWithoutClientEKU = mustSignCSR(validCSR, 24*time.Hour, func(template *x509.Certificate) {
+		template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+	})
+	certExpired = mustSignCSR(validCSR, 24*time.Hour, func(template *x509.Certificate) {
+		template.NotBefore, template.NotAfter = fixedClockStart.Add(-48*time.Hour), fixedClockStart.Add(-24*time.Hour)
+	})
 )
 
+// mustGenerateCA returns the key and PEM encoded certificate of a self signed CA.
+func mustGenerateCA() (crypto.Signer, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             fixedClockStart.Add(-time.Hour),
+		NotAfter:              fixedClockStart.Add(10 * 365 * 24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		panic(err)
+	}
+	return key, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+// mustSignCSR returns a PEM encoded certificate for csr, signed by testCA and
+// valid for the given duration from fixedClockStart. It can be modified by mods.
+func mustSignCSR(csrPEM []byte, validity time.Duration, mods ...func(*x509.Certificate)) []byte {
+	csr, err := signer.ParseCSR(csrPEM)
+	if err != nil {
+		panic(err)
+	}
+	ca, err := pki.DecodeX509CertificateBytes(testCA)
+	if err != nil {
+		panic(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(2),
+		Subject:               csr.Subject,
+		DNSNames:              csr.DNSNames,
+		NotBefore:             fixedClockStart,
+		NotAfter:              fixedClockStart.Add(validity),
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	for _, mod := range mods {
+		mod(template)
+	}
+	if template.IsCA {
+		template.KeyUsage |= x509.KeyUsageCertSign
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, csr.PublicKey, testCAKey)
+	if err != nil {
+		panic(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func mustGenerateCSR() []byte {
+	csr, _, err := cmgen.CSR(x509.ECDSA, cmgen.SetCSRDNSNames("example.com"))
+	if err != nil {
+		panic(err)
+	}
+	return csr
+}
+
+// mustTamperCSRSignature returns csr with a modified signature.
+func mustTamperCSRSignature(csr []byte) []byte {
+	block, _ := pem.Decode(csr)
+	if block == nil {
+		panic("invalid CSR")
+	}
+	der := append([]byte{}, block.Bytes...)
+	der[len(der)-1] ^= 0xff
+	return pem.EncodeToMemory(&pem.Block{Type: block.Type, Bytes: der})
+}
+
 type fakeSigner struct {
 	errSign error
+	// Returned instead of validCertificate and testCA if set
+	cert []byte
+	ca   []byte
+	// Profile the request is expected to ask for
+	expectProfile string
+	// Label the request is expected to ask for, which is then reported as the
+	// signing label instead of testSignerLabel
+	expectLabel string
 }
 
-func (o *fakeSigner) Sign(context.Context, []byte) ([]byte, []byte, error) {
-	return []byte("fake signer CA"), []byte("fake signed certificate"), o.errSign
+// Label of the signer reported by fakeSigner
+const testSignerLabel = "test-label"
+
+func (o *fakeSigner) Sign(_ context.Context, req *signer.SignRequest) (*signer.SignResult, error) {
+	if req.Profile != o.expectProfile {
+		return nil, fmt.Errorf("unexpected profile %q", req.Profile)
+	}
+	if o.expectLabel != "" && req.Label != o.expectLabel {
+		return nil, fmt.Errorf("unexpected label %q", req.Label)
+	}
+	if o.errSign != nil {
+		return nil, o.errSign
+	}
+	result := &signer.SignResult{CA: testCA, Certificate: validCertificate, Label: testSignerLabel}
+	if o.expectLabel != "" {
+		result.Label = o.expectLabel
+	}
+	if o.ca != nil {
+		result.CA = o.ca
+	}
+	if o.cert != nil {
+		result.Certificate = o.cert
+	}
+	return result, nil
 }
 
 func TestCertificateRequestReconcile(t *testing.T) {
@@ -60,6 +195,11 @@ func TestCertificateRequestReconcile(t *testing.T) {
 		expectedReadyConditionReason string
 		expectedFailureTime          *metav1.Time
 		expectedCertificate          []byte
+		// Events expected in addition to the one matching the Ready condition
+		expectedEvents []string
+		// Label recorded for issued certificates, defaults to testSignerLabel
+		expectedLabel         string
+		expectedRolloutStatus *cfsslissuerapi.RolloutStatus
 	}
 	tests := map[string]testCase{
 		"success-issuer": {
@@ -68,6 +208,1058 @@ func TestCertificateRequestReconcile(t *testing.T) {
 				cmgen.CertificateRequest(
 					"cr1",
 					cmgen.SetCertificateRequestNamespace("ns1"),
+					cmgen.SetCertificateRequestCSR(validCSR),
+					cmgen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+						Name:  "issuer1",
+						Group: cfsslissuerapi.GroupVersion.Group,
+						Kind:  "Issuer",
+					}),
+					cmgen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+						Type:   cmapi.CertificateRequestConditionApproved,
+						Status: cmmeta.ConditionTrue,
+					}),
+					cmgen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+						Type:   cmapi.CertificateRequestConditionReady,
+						Status: cmmeta.ConditionUnknown,
+					}),
+				),
+			},
+			issuerObjects: []client.Object{&cfsslissuerapi.Issuer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "issuer1",
+					Namespace: "ns1",
+				},
+				Spec: cfsslissuerapi.IssuerSpec{
+					AuthSecretName: "issuer1-credentials",
+				},
+				Status: cfsslissuerapi.IssuerStatus{
+					Conditions: []metav1.Condition{
+						{
+							Type:   cfsslissuerapi.IssuerConditionReady,
+							Status: metav1.ConditionTrue,
+						},
+					},
+				},
+			},
+			},
+			secretObjects: []client.Object{&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "issuer1-credentials",
+					Namespace: "ns1",
+				},
+			},
+			},
+			signerBuilder: func(*cfsslissuerapi.IssuerSpec, *signer.IssuerData) (signer.Signer, error) {
+				return &fakeSigner{}, nil
+			},
+			expectedReadyConditionStatus: cmmeta.ConditionTrue,
+			expectedReadyConditionReason: cmapi.CertificateRequestReasonIssued,
+			expectedFailureTime:          nil,
+			expectedCertificate:          validCertificate,
+		},
+		"success-issuer-rollout-secondary": {
+			name: types.NamespacedName{Namespace: "ns1", Name: "cr1"},
+			crObjects: []client.Object{
+				cmgen.CertificateRequest(
+					"cr1",
+					cmgen.SetCertificateRequestNamespace("ns1"),
+					cmgen.SetCertificateRequestCSR(validCSR),
+					cmgen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+						Name:  "issuer1",
+						Group: cfsslissuerapi.GroupVersion.Group,
+						Kind:  "Issuer",
+					}),
+					cmgen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+						Type:   cmapi.CertificateRequestConditionApproved,
+						Status: cmmeta.ConditionTrue,
+					}),
+					cmgen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+						Type:   cmapi.CertificateRequestConditionReady,
+						Status: cmmeta.ConditionUnknown,
+					}),
+				),
+			},
+			issuerObjects: []client.Object{&cfsslissuerapi.Issuer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "issuer1",
+					Namespace: "ns1",
+				},
+				Spec: cfsslissuerapi.IssuerSpec{
+					AuthSecretName: "issuer1-credentials",
+					Label:          "primary-label",
+					Rollout: &cfsslissuerapi.LabelRollout{
+						Label:  "secondary-label",
+						Weight: 100,
+					},
+				},
+				Status: cfsslissuerapi.IssuerStatus{
+					Conditions: []metav1.Condition{
+						{
+							Type:   cfsslissuerapi.IssuerConditionReady,
+							Status: metav1.ConditionTrue,
+						},
+					},
+				},
+			},
+			},
+			secretObjects: []client.Object{&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "issuer1-credentials",
+					Namespace: "ns1",
+				},
+			},
+			},
+			signerBuilder: func(*cfsslissuerapi.IssuerSpec, *signer.IssuerData) (signer.Signer, error) {
+				return &fakeSigner{expectLabel: "secondary-label"}, nil
+			},
+			expectedReadyConditionStatus: cmmeta.ConditionTrue,
+			expectedReadyConditionReason: cmapi.CertificateRequestReasonIssued,
+			expectedFailureTime:          nil,
+			expectedCertificate:          validCertificate,
+			expectedLabel:                "secondary-label",
+			expectedRolloutStatus: &cfsslissuerapi.RolloutStatus{
+				Label:             "secondary-label",
+				Weight:            100,
+				SecondaryRequests: 1,
+			},
+		},
+		"success-issuer-rollout-primary": {
+			name: types.NamespacedName{Namespace: "ns1", Name: "cr1"},
+			crObjects: []client.Object{
+				cmgen.CertificateRequest(
+					"cr1",
+					cmgen.SetCertificateRequestNamespace("ns1"),
+					cmgen.SetCertificateRequestCSR(validCSR),
+					cmgen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+						Name:  "issuer1",
+						Group: cfsslissuerapi.GroupVersion.Group,
+						Kind:  "Issuer",
+					}),
+					cmgen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+						Type:   cmapi.CertificateRequestConditionApproved,
+						Status: cmmeta.ConditionTrue,
+					}),
+					cmgen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+						Type:   cmapi.CertificateRequestConditionReady,
+						Status: cmmeta.ConditionUnknown,
+					}),
+				),
+			},
+			issuerObjects: []client.Object{&cfsslissuerapi.Issuer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "issuer1",
+					Namespace: "ns1",
+				},
+				Spec: cfsslissuerapi.IssuerSpec{
+					AuthSecretName: "issuer1-credentials",
+					Label:          "primary-label",
+					Rollout: &cfsslissuerapi.LabelRollout{
+						Label:  "secondary-label",
+						Weight: 0,
+					},
+				},
+				Status: cfsslissuerapi.IssuerStatus{
+					Conditions: []metav1.Condition{
+						{
+							Type:   cfsslissuerapi.IssuerConditionReady,
+							Status: metav1.ConditionTrue,
+						},
+					},
+				},
+			},
+			},
+			secretObjects: []client.Object{&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "issuer1-credentials",
+					Namespace: "ns1",
+				},
+			},
+			},
+			signerBuilder: func(*cfsslissuerapi.IssuerSpec, *signer.IssuerData) (signer.Signer, error) {
+				return &fakeSigner{expectLabel: "primary-label"}, nil
+			},
+			expectedReadyConditionStatus: cmmeta.ConditionTrue,
+			expectedReadyConditionReason: cmapi.CertificateRequestReasonIssued,
+			expectedFailureTime:          nil,
+			expectedCertificate:          validCertificate,
+			expectedLabel:                "primary-label",
+			expectedRolloutStatus: &cfsslissuerapi.RolloutStatus{
+				Label:           "secondary-label",
+				Weight:          0,
+				PrimaryRequests: 1,
+			},
+		},
+		"verification-public-key-mismatch": {
+			name: types.NamespacedName{Namespace: "ns1", Name: "cr1"},
+			crObjects: []client.Object{
+				cmgen.CertificateRequest(
+					"cr1",
+					cmgen.SetCertificateRequestNamespace("ns1"),
+					cmgen.SetCertificateRequestCSR(validCSR),
+					cmgen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+						Name:  "issuer1",
+						Group: cfsslissuerapi.GroupVersion.Group,
+						Kind:  "Issuer",
+					}),
+					cmgen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+						Type:   cmapi.CertificateRequestConditionApproved,
+						Status: cmmeta.ConditionTrue,
+					}),
+					cmgen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+						Type:   cmapi.CertificateRequestConditionReady,
+						Status: cmmeta.ConditionUnknown,
+					}),
+				),
+			},
+			issuerObjects: []client.Object{&cfsslissuerapi.Issuer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "issuer1",
+					Namespace: "ns1",
+				},
+				Spec: cfsslissuerapi.IssuerSpec{
+					AuthSecretName: "issuer1-credentials",
+				},
+				Status: cfsslissuerapi.IssuerStatus{
+					Conditions: []metav1.Condition{
+						{
+							Type:   cfsslissuerapi.IssuerConditionReady,
+							Status: metav1.ConditionTrue,
+						},
+					},
+				},
+			},
+			},
+			secretObjects: []client.Object{&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "issuer1-credentials",
+					Namespace: "ns1",
+				},
+			},
+			},
+			signerBuilder: func(*cfsslissuerapi.IssuerSpec, *signer.IssuerData) (signer.Signer, error) {
+				return &fakeSigner{cert: certForOtherCSR}, nil
+			},
+			expectedReadyConditionStatus: cmmeta.ConditionFalse,
+			expectedReadyConditionReason: cmapi.CertificateRequestReasonFailed,
+			expectedFailureTime:          &nowMetaTime,
+		},
+		"verification-san-mismatch": {
+			name: types.NamespacedName{Namespace: "ns1", Name: "cr1"},
+			crObjects: []client.Object{
+				cmgen.CertificateRequest(
+					"cr1",
+					cmgen.SetCertificateRequestNamespace("ns1"),
+					cmgen.SetCertificateRequestCSR(validCSR),
+					cmgen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+						Name:  "issuer1",
+						Group: cfsslissuerapi.GroupVersion.Group,
+						Kind:  "Issuer",
+					}),
+					cmgen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+						Type:   cmapi.CertificateRequestConditionApproved,
+						Status: cmmeta.ConditionTrue,
+					}),
+					cmgen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+						Type:   cmapi.CertificateRequestConditionReady,
+						Status: cmmeta.ConditionUnknown,
+					}),
+				),
+			},
+			issuerObjects: []client.Object{&cfsslissuerapi.Issuer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "issuer1",
+					Namespace: "ns1",
+				},
+				Spec: cfsslissuerapi.IssuerSpec{
+					AuthSecretName: "issuer1-credentials",
+				},
+				Status: cfsslissuerapi.IssuerStatus{
+					Conditions: []metav1.Condition{
+						{
+							Type:   cfsslissuerapi.IssuerConditionReady,
+							Status: metav1.ConditionTrue,
+						},
+					},
+				},
+			},
+			},
+			secretObjects: []client.Object{&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "issuer1-credentials",
+					Namespace: "ns1",
+				},
+			},
+			},
+			signerBuilder: func(*cfsslissuerapi.IssuerSpec, *signer.IssuerData) (signer.Signer, error) {
+				return &fakeSigner{cert: certWithExtraSAN}, nil
+			},
+			expectedReadyConditionStatus: cmmeta.ConditionFalse,
+			expectedReadyConditionReason: cmapi.CertificateRequestReasonFailed,
+			expectedFailureTime:          &nowMetaTime,
+		},
+		"verification-usage-mismatch": {
+			name: types.NamespacedName{Namespace: "ns1", Name: "cr1"},
+			crObjects: []client.Object{
+				cmgen.CertificateRequest(
+					"cr1",
+					cmgen.SetCertificateRequestNamespace("ns1"),
+					cmgen.SetCertificateRequestCSR(validCSR),
+					cmgen.SetCertificateRequestKeyUsages(cmapi.UsageDigitalSignature, cmapi.UsageClientAuth),
+					cmgen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+						Name:  "issuer1",
+						Group: cfsslissuerapi.GroupVersion.Group,
+						Kind:  "Issuer",
+					}),
+					cmgen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+						Type:   cmapi.CertificateRequestConditionApproved,
+						Status: cmmeta.ConditionTrue,
+					}),
+					cmgen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+						Type:   cmapi.CertificateRequestConditionReady,
+						Status: cmmeta.ConditionUnknown,
+					}),
+				),
+			},
+			issuerObjects: []client.Object{&cfsslissuerapi.Issuer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "issuer1",
+					Namespace: "ns1",
+				},
+				Spec: cfsslissuerapi.IssuerSpec{
+					AuthSecretName: "issuer1-credentials",
+				},
+				Status: cfsslissuerapi.IssuerStatus{
+					Conditions: []metav1.Condition{
+						{
+							Type:   cfsslissuerapi.IssuerConditionReady,
+							Status: metav1.ConditionTrue,
+						},
+					},
+				},
+			},
+			},
+			secretObjects: []client.Object{&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "issuer1-credentials",
+					Namespace: "ns1",
+				},
+			},
+			},
+			signerBuilder: func(*cfsslissuerapi.IssuerSpec, *signer.IssuerData) (signer.Signer, error) {
+				return &fakeSigner{cert: certWithoutClientEKU}, nil
+			},
+			expectedReadyConditionStatus: cmmeta.ConditionFalse,
+			expectedReadyConditionReason: cmapi.CertificateRequestReasonFailed,
+			expectedFailureTime:          &nowMetaTime,
+		},
+		"verification-ca-mismatch": {
+			name: types.NamespacedName{Namespace: "ns1", Name: "cr1"},
+			crObjects: []client.Object{
+				cmgen.CertificateRequest(
+					"cr1",
+					cmgen.SetCertificateRequestNamespace("ns1"),
+					cmgen.SetCertificateRequestCSR(validCSR),
+					cmgen.SetCertificateRequestIsCA(true),
+					cmgen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+						Name:  "issuer1",
+						Group: cfsslissuerapi.GroupVersion.Group,
+						Kind:  "Issuer",
+					}),
+					cmgen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+						Type:   cmapi.CertificateRequestConditionApproved,
+						Status: cmmeta.ConditionTrue,
+					}),
+					cmgen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+						Type:   cmapi.CertificateRequestConditionReady,
+						Status: cmmeta.ConditionUnknown,
+					}),
+				),
+			},
+			issuerObjects: []client.Object{&cfsslissuerapi.Issuer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "issuer1",
+					Namespace: "ns1",
+				},
+				Spec: cfsslissuerapi.IssuerSpec{
+					AuthSecretName: "issuer1-credentials",
+				},
+				Status: cfsslissuerapi.IssuerStatus{
+					Conditions: []metav1.Condition{
+						{
+							Type:   cfsslissuerapi.IssuerConditionReady,
+							Status: metav1.ConditionTrue,
+						},
+					},
+				},
+			},
+			},
+			secretObjects: []client.Object{&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "issuer1-credentials",
+					Namespace: "ns1",
+				},
+			},
+			},
+			signerBuilder: func(*cfsslissuerapi.IssuerSpec, *signer.IssuerData) (signer.Signer, error) {
+				return &fakeSigner{}, nil
+			},
+			expectedReadyConditionStatus: cmmeta.ConditionFalse,
+			expectedReadyConditionReason: cmapi.CertificateRequestReasonFailed,
+			expectedFailureTime:          &nowMetaTime,
+		},
+		"verification-untrusted": {
+			name: types.NamespacedName{Namespace: "ns1", Name: "cr1"},
+			crObjects: []client.Object{
+				cmgen.CertificateRequest(
+					"cr1",
+					cmgen.SetCertificateRequestNamespace("ns1"),
+					cmgen.SetCertificateRequestCSR(validCSR),
+					cmgen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+						Name:  "issuer1",
+						Group: cfsslissuerapi.GroupVersion.Group,
+						Kind:  "Issuer",
+					}),
+					cmgen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+						Type:   cmapi.CertificateRequestConditionApproved,
+						Status: cmmeta.ConditionTrue,
+					}),
+					cmgen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+						Type:   cmapi.CertificateRequestConditionReady,
+						Status: cmmeta.ConditionUnknown,
+					}),
+				),
+			},
+			issuerObjects: []client.Object{&cfsslissuerapi.Issuer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "issuer1",
+					Namespace: "ns1",
+				},
+				Spec: cfsslissuerapi.IssuerSpec{
+					AuthSecretName: "issuer1-credentials",
+				},
+				Status: cfsslissuerapi.IssuerStatus{
+					Conditions: []metav1.Condition{
+						{
+							Type:   cfsslissuerapi.IssuerConditionReady,
+							Status: metav1.ConditionTrue,
+						},
+					},
+				},
+			},
+			},
+			secretObjects: []client.Object{&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "issuer1-credentials",
+					Namespace: "ns1",
+				},
+			},
+			},
+			signerBuilder: func(*cfsslissuerapi.IssuerSpec, *signer.IssuerData) (signer.Signer, error) {
+				return &fakeSigner{ca: otherCA}, nil
+			},
+			expectedReadyConditionStatus: cmmeta.ConditionFalse,
+			expectedReadyConditionReason: cmapi.CertificateRequestReasonFailed,
+			expectedFailureTime:          &nowMetaTime,
+		},
+		"verification-expired": {
+			name: types.NamespacedName{Namespace: "ns1", Name: "cr1"},
+			crObjects: []client.Object{
+				cmgen.CertificateRequest(
+					"cr1",
+					cmgen.SetCertificateRequestNamespace("ns1"),
+					cmgen.SetCertificateRequestCSR(validCSR),
+					cmgen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+						Name:  "issuer1",
+						Group: cfsslissuerapi.GroupVersion.Group,
+						Kind:  "Issuer",
+					}),
+					cmgen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+						Type:   cmapi.CertificateRequestConditionApproved,
+						Status: cmmeta.ConditionTrue,
+					}),
+					cmgen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+						Type:   cmapi.CertificateRequestConditionReady,
+						Status: cmmeta.ConditionUnknown,
+					}),
+				),
+			},
+			issuerObjects: []client.Object{&cfsslissuerapi.Issuer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "issuer1",
+					Namespace: "ns1",
+				},
+				Spec: cfsslissuerapi.IssuerSpec{
+					AuthSecretName: "issuer1-credentials",
+				},
+				Status: cfsslissuerapi.IssuerStatus{
+					Conditions: []metav1.Condition{
+						{
+							Type:   cfsslissuerapi.IssuerConditionReady,
+							Status: metav1.ConditionTrue,
+						},
+					},
+				},
+			},
+			},
+			secretObjects: []client.Object{&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "issuer1-credentials",
+					Namespace: "ns1",
+				},
+			},
+			},
+			signerBuilder: func(*cfsslissuerapi.IssuerSpec, *signer.IssuerData) (signer.Signer, error) {
+				return &fakeSigner{cert: certExpired}, nil
+			},
+			expectedReadyConditionStatus: cmmeta.ConditionFalse,
+			expectedReadyConditionReason: cmapi.CertificateRequestReasonFailed,
+			expectedFailureTime:          &nowMetaTime,
+		},
+		"success-profile-selector-ca": {
+			name: types.NamespacedName{Namespace: "ns1", Name: "cr1"},
+			crObjects: []client.Object{
+				cmgen.CertificateRequest(
+					"cr1",
+					cmgen.SetCertificateRequestNamespace("ns1"),
+					cmgen.SetCertificateRequestCSR(validCSR),
+					cmgen.SetCertificateRequestIsCA(true),
+					cmgen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+						Name:  "issuer1",
+						Group: cfsslissuerapi.GroupVersion.Group,
+						Kind:  "Issuer",
+					}),
+					cmgen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+						Type:   cmapi.CertificateRequestConditionApproved,
+						Status: cmmeta.ConditionTrue,
+					}),
+					cmgen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+						Type:   cmapi.CertificateRequestConditionReady,
+						Status: cmmeta.ConditionUnknown,
+					}),
+				),
+			},
+			issuerObjects: []client.Object{&cfsslissuerapi.Issuer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "issuer1",
+					Namespace: "ns1",
+				},
+				Spec: cfsslissuerapi.IssuerSpec{
+					AuthSecretName: "issuer1-credentials",
+					Profile:        "server",
+					ProfileSelectors: []cfsslissuerapi.ProfileSelector{
+						{Profile: "intermediate_ca", IsCA: pointer.Bool(true)},
+						{Profile: "server", IsCA: pointer.Bool(false), Usages: []string{"digital signature", "key encipherment", "server auth"}},
+						{Profile: "client", IsCA: pointer.Bool(false), Usages: []string{"digital signature", "key encipherment", "client auth"}},
+					},
+				},
+				Status: cfsslissuerapi.IssuerStatus{
+					Conditions: []metav1.Condition{
+						{
+							Type:   cfsslissuerapi.IssuerConditionReady,
+							Status: metav1.ConditionTrue,
+						},
+					},
+				},
+			},
+			},
+			secretObjects: []client.Object{&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "issuer1-credentials",
+					Namespace: "ns1",
+				},
+			},
+			},
+			signerBuilder: func(*cfsslissuerapi.IssuerSpec, *signer.IssuerData) (signer.Signer, error) {
+				return &fakeSigner{expectProfile: "intermediate_ca", cert: validCACertificate}, nil
+			},
+			expectedReadyConditionStatus: cmmeta.ConditionTrue,
+			expectedReadyConditionReason: cmapi.CertificateRequestReasonIssued,
+			expectedFailureTime:          nil,
+			expectedCertificate:          validCACertificate,
+		},
+		"success-profile-selector-default-usages": {
+			name: types.NamespacedName{Namespace: "ns1", Name: "cr1"},
+			crObjects: []client.Object{
+				cmgen.CertificateRequest(
+					"cr1",
+					cmgen.SetCertificateRequestNamespace("ns1"),
+					cmgen.SetCertificateRequestCSR(validCSR),
+					cmgen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+						Name:  "issuer1",
+						Group: cfsslissuerapi.GroupVersion.Group,
+						Kind:  "Issuer",
+					}),
+					cmgen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+						Type:   cmapi.CertificateRequestConditionApproved,
+						Status: cmmeta.ConditionTrue,
+					}),
+					cmgen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+						Type:   cmapi.CertificateRequestConditionReady,
+						Status: cmmeta.ConditionUnknown,
+					}),
+				),
+			},
+			issuerObjects: []client.Object{&cfsslissuerapi.Issuer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "issuer1",
+					Namespace: "ns1",
+				},
+				Spec: cfsslissuerapi.IssuerSpec{
+					AuthSecretName: "issuer1-credentials",
+					Profile:        "server",
+					ProfileSelectors: []cfsslissuerapi.ProfileSelector{
+						{Profile: "intermediate_ca", IsCA: pointer.Bool(true)},
+						{Profile: "server", IsCA: pointer.Bool(false), Usages: []string{"digital signature", "key encipherment", "server auth"}},
+						{Profile: "client", IsCA: pointer.Bool(false), Usages: []string{"digital signature", "key encipherment", "client auth"}},
+					},
+				},
+				Status: cfsslissuerapi.IssuerStatus{
+					Conditions: []metav1.Condition{
+						{
+							Type:   cfsslissuerapi.IssuerConditionReady,
+							Status: metav1.ConditionTrue,
+						},
+					},
+				},
+			},
+			},
+			secretObjects: []client.Object{&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "issuer1-credentials",
+					Namespace: "ns1",
+				},
+			},
+			},
+			signerBuilder: func(*cfsslissuerapi.IssuerSpec, *signer.IssuerData) (signer.Signer, error) {
+				return &fakeSigner{expectProfile: "server"}, nil
+			},
+			expectedReadyConditionStatus: cmmeta.ConditionTrue,
+			expectedReadyConditionReason: cmapi.CertificateRequestReasonIssued,
+			expectedFailureTime:          nil,
+			expectedCertificate:          validCertificate,
+		},
+		"success-profile-selector-client": {
+			name: types.NamespacedName{Namespace: "ns1", Name: "cr1"},
+			crObjects: []client.Object{
+				cmgen.CertificateRequest(
+					"cr1",
+					cmgen.SetCertificateRequestNamespace("ns1"),
+					cmgen.SetCertificateRequestCSR(validCSR),
+					cmgen.SetCertificateRequestKeyUsages(cmapi.UsageDigitalSignature, cmapi.UsageClientAuth),
+					cmgen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+						Name:  "issuer1",
+						Group: cfsslissuerapi.GroupVersion.Group,
+						Kind:  "Issuer",
+					}),
+					cmgen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+						Type:   cmapi.CertificateRequestConditionApproved,
+						Status: cmmeta.ConditionTrue,
+					}),
+					cmgen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+						Type:   cmapi.CertificateRequestConditionReady,
+						Status: cmmeta.ConditionUnknown,
+					}),
+				),
+			},
+			issuerObjects: []client.Object{&cfsslissuerapi.Issuer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "issuer1",
+					Namespace: "ns1",
+				},
+				Spec: cfsslissuerapi.IssuerSpec{
+					AuthSecretName: "issuer1-credentials",
+					Profile:        "server",
+					ProfileSelectors: []cfsslissuerapi.ProfileSelector{
+						{Profile: "intermediate_ca", IsCA: pointer.Bool(true)},
+						{Profile: "server", IsCA: pointer.Bool(false), Usages: []string{"digital signature", "key encipherment", "server auth"}},
+						{Profile: "client", IsCA: pointer.Bool(false), Usages: []string{"digital signature", "key encipherment", "client auth"}},
+					},
+				},
+				Status: cfsslissuerapi.IssuerStatus{
+					Conditions: []metav1.Condition{
+						{
+							Type:   cfsslissuerapi.IssuerConditionReady,
+							Status: metav1.ConditionTrue,
+						},
+					},
+				},
+			},
+			},
+			secretObjects: []client.Object{&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "issuer1-credentials",
+					Namespace: "ns1",
+				},
+			},
+			},
+			signerBuilder: func(*cfsslissuerapi.IssuerSpec, *signer.IssuerData) (signer.Signer, error) {
+				return &fakeSigner{expectProfile: "client"}, nil
+			},
+			expectedReadyConditionStatus: cmmeta.ConditionTrue,
+			expectedReadyConditionReason: cmapi.CertificateRequestReasonIssued,
+			expectedFailureTime:          nil,
+			expectedCertificate:          validCertificate,
+		},
+		"profile-selector-no-match": {
+			name: types.NamespacedName{Namespace: "ns1", Name: "cr1"},
+			crObjects: []client.Object{
+				cmgen.CertificateRequest(
+					"cr1",
+					cmgen.SetCertificateRequestNamespace("ns1"),
+					cmgen.SetCertificateRequestCSR(validCSR),
+					cmgen.SetCertificateRequestKeyUsages(cmapi.UsageServerAuth, cmapi.UsageClientAuth),
+					cmgen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+						Name:  "issuer1",
+						Group: cfsslissuerapi.GroupVersion.Group,
+						Kind:  "Issuer",
+					}),
+					cmgen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+						Type:   cmapi.CertificateRequestConditionApproved,
+						Status: cmmeta.ConditionTrue,
+					}),
+					cmgen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+						Type:   cmapi.CertificateRequestConditionReady,
+						Status: cmmeta.ConditionUnknown,
+					}),
+				),
+			},
+			issuerObjects: []client.Object{&cfsslissuerapi.Issuer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "issuer1",
+					Namespace: "ns1",
+				},
+				Spec: cfsslissuerapi.IssuerSpec{
+					AuthSecretName: "issuer1-credentials",
+					Profile:        "server",
+					ProfileSelectors: []cfsslissuerapi.ProfileSelector{
+						{Profile: "intermediate_ca", IsCA: pointer.Bool(true)},
+						{Profile: "server", IsCA: pointer.Bool(false), Usages: []string{"digital signature", "key encipherment", "server auth"}},
+						{Profile: "client", IsCA: pointer.Bool(false), Usages: []string{"digital signature", "key encipherment", "client auth"}},
+					},
+				},
+				Status: cfsslissuerapi.IssuerStatus{
+					Conditions: []metav1.Condition{
+						{
+							Type:   cfsslissuerapi.IssuerConditionReady,
+							Status: metav1.ConditionTrue,
+						},
+					},
+				},
+			},
+			},
+			secretObjects: []client.Object{&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "issuer1-credentials",
+					Namespace: "ns1",
+				},
+			},
+			},
+			signerBuilder: func(*cfsslissuerapi.IssuerSpec, *signer.IssuerData) (signer.Signer, error) {
+				return &fakeSigner{expectProfile: ""}, nil
+			},
+			expectedReadyConditionStatus: cmmeta.ConditionFalse,
+			expectedReadyConditionReason: cmapi.CertificateRequestReasonFailed,
+			expectedFailureTime:          &nowMetaTime,
+		},
+		"success-profile-allowed": {
+			name: types.NamespacedName{Namespace: "ns1", Name: "cr1"},
+			crObjects: []client.Object{
+				cmgen.CertificateRequest(
+					"cr1",
+					cmgen.SetCertificateRequestNamespace("ns1"),
+					cmgen.SetCertificateRequestCSR(validCSR),
+					cmgen.SetCertificateRequestAnnotations(map[string]string{
+						cfsslissuerapi.ProfileAnnotationKey: "client",
+					}),
+					cmgen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+						Name:  "issuer1",
+						Group: cfsslissuerapi.GroupVersion.Group,
+						Kind:  "Issuer",
+					}),
+					cmgen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+						Type:   cmapi.CertificateRequestConditionApproved,
+						Status: cmmeta.ConditionTrue,
+					}),
+					cmgen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+						Type:   cmapi.CertificateRequestConditionReady,
+						Status: cmmeta.ConditionUnknown,
+					}),
+				),
+			},
+			issuerObjects: []client.Object{&cfsslissuerapi.Issuer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "issuer1",
+					Namespace: "ns1",
+				},
+				Spec: cfsslissuerapi.IssuerSpec{
+					AuthSecretName:  "issuer1-credentials",
+					Profile:         "server",
+					AllowedProfiles: []string{"client", "intermediate_ca"},
+				},
+				Status: cfsslissuerapi.IssuerStatus{
+					Conditions: []metav1.Condition{
+						{
+							Type:   cfsslissuerapi.IssuerConditionReady,
+							Status: metav1.ConditionTrue,
+						},
+					},
+				},
+			},
+			},
+			secretObjects: []client.Object{&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "issuer1-credentials",
+					Namespace: "ns1",
+				},
+			},
+			},
+			signerBuilder: func(*cfsslissuerapi.IssuerSpec, *signer.IssuerData) (signer.Signer, error) {
+				return &fakeSigner{expectProfile: "client"}, nil
+			},
+			expectedReadyConditionStatus: cmmeta.ConditionTrue,
+			expectedReadyConditionReason: cmapi.CertificateRequestReasonIssued,
+			expectedFailureTime:          nil,
+			expectedCertificate:          validCertificate,
+		},
+		"success-profile-default": {
+			name: types.NamespacedName{Namespace: "ns1", Name: "cr1"},
+			crObjects: []client.Object{
+				cmgen.CertificateRequest(
+					"cr1",
+					cmgen.SetCertificateRequestNamespace("ns1"),
+					cmgen.SetCertificateRequestCSR(validCSR),
+					cmgen.SetCertificateRequestAnnotations(map[string]string{
+						cfsslissuerapi.ProfileAnnotationKey: "server",
+					}),
+					cmgen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+						Name:  "issuer1",
+						Group: cfsslissuerapi.GroupVersion.Group,
+						Kind:  "Issuer",
+					}),
+					cmgen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+						Type:   cmapi.CertificateRequestConditionApproved,
+						Status: cmmeta.ConditionTrue,
+					}),
+					cmgen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+						Type:   cmapi.CertificateRequestConditionReady,
+						Status: cmmeta.ConditionUnknown,
+					}),
+				),
+			},
+			issuerObjects: []client.Object{&cfsslissuerapi.Issuer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "issuer1",
+					Namespace: "ns1",
+				},
+				Spec: cfsslissuerapi.IssuerSpec{
+					AuthSecretName:  "issuer1-credentials",
+					Profile:         "server",
+					AllowedProfiles: []string{"client", "intermediate_ca"},
+				},
+				Status: cfsslissuerapi.IssuerStatus{
+					Conditions: []metav1.Condition{
+						{
+							Type:   cfsslissuerapi.IssuerConditionReady,
+							Status: metav1.ConditionTrue,
+						},
+					},
+				},
+			},
+			},
+			secretObjects: []client.Object{&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "issuer1-credentials",
+					Namespace: "ns1",
+				},
+			},
+			},
+			signerBuilder: func(*cfsslissuerapi.IssuerSpec, *signer.IssuerData) (signer.Signer, error) {
+				return &fakeSigner{expectProfile: ""}, nil
+			},
+			expectedReadyConditionStatus: cmmeta.ConditionTrue,
+			expectedReadyConditionReason: cmapi.CertificateRequestReasonIssued,
+			expectedFailureTime:          nil,
+			expectedCertificate:          validCertificate,
+		},
+		"profile-not-allowed": {
+			name: types.NamespacedName{Namespace: "ns1", Name: "cr1"},
+			crObjects: []client.Object{
+				cmgen.CertificateRequest(
+					"cr1",
+					cmgen.SetCertificateRequestNamespace("ns1"),
+					cmgen.SetCertificateRequestCSR(validCSR),
+					cmgen.SetCertificateRequestAnnotations(map[string]string{
+						cfsslissuerapi.ProfileAnnotationKey: "admin",
+					}),
+					cmgen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+						Name:  "issuer1",
+						Group: cfsslissuerapi.GroupVersion.Group,
+						Kind:  "Issuer",
+					}),
+					cmgen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+						Type:   cmapi.CertificateRequestConditionApproved,
+						Status: cmmeta.ConditionTrue,
+					}),
+					cmgen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+						Type:   cmapi.CertificateRequestConditionReady,
+						Status: cmmeta.ConditionUnknown,
+					}),
+				),
+			},
+			issuerObjects: []client.Object{&cfsslissuerapi.Issuer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "issuer1",
+					Namespace: "ns1",
+				},
+				Spec: cfsslissuerapi.IssuerSpec{
+					AuthSecretName:  "issuer1-credentials",
+					Profile:         "server",
+					AllowedProfiles: []string{"client", "intermediate_ca"},
+				},
+				Status: cfsslissuerapi.IssuerStatus{
+					Conditions: []metav1.Condition{
+						{
+							Type:   cfsslissuerapi.IssuerConditionReady,
+							Status: metav1.ConditionTrue,
+						},
+					},
+				},
+			},
+			},
+			secretObjects: []client.Object{&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "issuer1-credentials",
+					Namespace: "ns1",
+				},
+			},
+			},
+			signerBuilder: func(*cfsslissuerapi.IssuerSpec, *signer.IssuerData) (signer.Signer, error) {
+				return &fakeSigner{expectProfile: ""}, nil
+			},
+			expectedReadyConditionStatus: cmmeta.ConditionFalse,
+			expectedReadyConditionReason: cmapi.CertificateRequestReasonFailed,
+			expectedFailureTime:          &nowMetaTime,
+		},
+		"success-duration": {
+			name: types.NamespacedName{Namespace: "ns1", Name: "cr1"},
+			crObjects: []client.Object{
+				cmgen.CertificateRequest(
+					"cr1",
+					cmgen.SetCertificateRequestNamespace("ns1"),
+					cmgen.SetCertificateRequestCSR(validCSR),
+					cmgen.SetCertificateRequestDuration(&metav1.Duration{Duration: 24 * time.Hour}),
+					cmgen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+						Name:  "issuer1",
+						Group: cfsslissuerapi.GroupVersion.Group,
+						Kind:  "Issuer",
+					}),
+					cmgen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+						Type:   cmapi.CertificateRequestConditionApproved,
+						Status: cmmeta.ConditionTrue,
+					}),
+					cmgen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+						Type:   cmapi.CertificateRequestConditionReady,
+						Status: cmmeta.ConditionUnknown,
+					}),
+				),
+			},
+			issuerObjects: []client.Object{&cfsslissuerapi.Issuer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "issuer1",
+					Namespace: "ns1",
+				},
+				Spec: cfsslissuerapi.IssuerSpec{
+					AuthSecretName: "issuer1-credentials",
+				},
+				Status: cfsslissuerapi.IssuerStatus{
+					Conditions: []metav1.Condition{
+						{
+							Type:   cfsslissuerapi.IssuerConditionReady,
+							Status: metav1.ConditionTrue,
+						},
+					},
+				},
+			},
+			},
+			secretObjects: []client.Object{&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "issuer1-credentials",
+					Namespace: "ns1",
+				},
+			},
+			},
+			signerBuilder: func(*cfsslissuerapi.IssuerSpec, *signer.IssuerData) (signer.Signer, error) {
+				return &fakeSigner{cert: certValidFor24h}, nil
+			},
+			expectedReadyConditionStatus: cmmeta.ConditionTrue,
+			expectedReadyConditionReason: cmapi.CertificateRequestReasonIssued,
+			expectedFailureTime:          nil,
+			expectedCertificate:          certValidFor24h,
+		},
+		"success-duration-backdated": {
+			name: types.NamespacedName{Namespace: "ns1", Name: "cr1"},
+			crObjects: []client.Object{
+				cmgen.CertificateRequest(
+					"cr1",
+					cmgen.SetCertificateRequestNamespace("ns1"),
+					cmgen.SetCertificateRequestCSR(validCSR),
+					cmgen.SetCertificateRequestDuration(&metav1.Duration{Duration: 24 * time.Hour}),
+					cmgen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+						Name:  "issuer1",
+						Group: cfsslissuerapi.GroupVersion.Group,
+						Kind:  "Issuer",
+					}),
+					cmgen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+						Type:   cmapi.CertificateRequestConditionApproved,
+						Status: cmmeta.ConditionTrue,
+					}),
+					cmgen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+						Type:   cmapi.CertificateRequestConditionReady,
+						Status: cmmeta.ConditionUnknown,
+					}),
+				),
+			},
+			issuerObjects: []client.Object{&cfsslissuerapi.Issuer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "issuer1",
+					Namespace: "ns1",
+				},
+				Spec: cfsslissuerapi.IssuerSpec{
+					AuthSecretName: "issuer1-credentials",
+				},
+				Status: cfsslissuerapi.IssuerStatus{
+					Conditions: []metav1.Condition{
+						{
+							Type:   cfsslissuerapi.IssuerConditionReady,
+							Status: metav1.ConditionTrue,
+						},
+					},
+				},
+			},
+			},
+			secretObjects: []client.Object{&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "issuer1-credentials",
+					Namespace: "ns1",
+				},
+			},
+			},
+			signerBuilder: func(*cfsslissuerapi.IssuerSpec, *signer.IssuerData) (signer.Signer, error) {
+				return &fakeSigner{cert: certBackdatedFor24h}, nil
+			},
+			expectedReadyConditionStatus: cmmeta.ConditionTrue,
+			expectedReadyConditionReason: cmapi.CertificateRequestReasonIssued,
+			expectedFailureTime:          nil,
+			expectedCertificate:          certBackdatedFor24h,
+		},
+		"success-duration-capped": {
+			name: types.NamespacedName{Namespace: "ns1", Name: "cr1"},
+			crObjects: []client.Object{
+				cmgen.CertificateRequest(
+					"cr1",
+					cmgen.SetCertificateRequestNamespace("ns1"),
+					cmgen.SetCertificateRequestCSR(validCSR),
+					cmgen.SetCertificateRequestDuration(&metav1.Duration{Duration: 24 * time.Hour}),
 					cmgen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
 						Name:  "issuer1",
 						Group: cfsslissuerapi.GroupVersion.Group,
@@ -90,12 +1282,13 @@ func TestCertificateRequestReconcile(t *testing.T) {
 				},
 				Spec: cfsslissuerapi.IssuerSpec{
 					AuthSecretName: "issuer1-credentials",
+					MaxDuration:    &metav1.Duration{Duration: time.Hour},
 				},
 				Status: cfsslissuerapi.IssuerStatus{
-					Conditions: []cfsslissuerapi.IssuerCondition{
+					Conditions: []metav1.Condition{
 						{
 							Type:   cfsslissuerapi.IssuerConditionReady,
-							Status: cfsslissuerapi.ConditionTrue,
+							Status: metav1.ConditionTrue,
 						},
 					},
 				},
@@ -108,13 +1301,132 @@ func TestCertificateRequestReconcile(t *testing.T) {
 				},
 			},
 			},
-			signerBuilder: func(*cfsslissuerapi.IssuerSpec, map[string][]byte) (signer.Signer, error) {
-				return &fakeSigner{}, nil
+			signerBuilder: func(*cfsslissuerapi.IssuerSpec, *signer.IssuerData) (signer.Signer, error) {
+				return &fakeSigner{cert: certValidFor1h}, nil
+			},
+			expectedReadyConditionStatus: cmmeta.ConditionTrue,
+			expectedReadyConditionReason: cmapi.CertificateRequestReasonIssued,
+			expectedFailureTime:          nil,
+			expectedCertificate:          certValidFor1h,
+		},
+		"duration-mismatch": {
+			name: types.NamespacedName{Namespace: "ns1", Name: "cr1"},
+			crObjects: []client.Object{
+				cmgen.CertificateRequest(
+					"cr1",
+					cmgen.SetCertificateRequestNamespace("ns1"),
+					cmgen.SetCertificateRequestCSR(validCSR),
+					cmgen.SetCertificateRequestDuration(&metav1.Duration{Duration: 24 * time.Hour}),
+					cmgen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+						Name:  "issuer1",
+						Group: cfsslissuerapi.GroupVersion.Group,
+						Kind:  "Issuer",
+					}),
+					cmgen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+						Type:   cmapi.CertificateRequestConditionApproved,
+						Status: cmmeta.ConditionTrue,
+					}),
+					cmgen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+						Type:   cmapi.CertificateRequestConditionReady,
+						Status: cmmeta.ConditionUnknown,
+					}),
+				),
+			},
+			issuerObjects: []client.Object{&cfsslissuerapi.Issuer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "issuer1",
+					Namespace: "ns1",
+				},
+				Spec: cfsslissuerapi.IssuerSpec{
+					AuthSecretName: "issuer1-credentials",
+				},
+				Status: cfsslissuerapi.IssuerStatus{
+					Conditions: []metav1.Condition{
+						{
+							Type:   cfsslissuerapi.IssuerConditionReady,
+							Status: metav1.ConditionTrue,
+						},
+					},
+				},
+			},
+			},
+			secretObjects: []client.Object{&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "issuer1-credentials",
+					Namespace: "ns1",
+				},
+			},
+			},
+			signerBuilder: func(*cfsslissuerapi.IssuerSpec, *signer.IssuerData) (signer.Signer, error) {
+				return &fakeSigner{cert: certValidFor1h}, nil
+			},
+			expectedReadyConditionStatus: cmmeta.ConditionTrue,
+			expectedReadyConditionReason: cmapi.CertificateRequestReasonIssued,
+			expectedFailureTime:          nil,
+			expectedCertificate:          certValidFor1h,
+			expectedEvents: []string{
+				"Warning DurationMismatch Issued certificate is valid for 1h0m0s, but 24h0m0s was requested",
+			},
+		},
+		"duration-mismatch-capped": {
+			name: types.NamespacedName{Namespace: "ns1", Name: "cr1"},
+			crObjects: []client.Object{
+				cmgen.CertificateRequest(
+					"cr1",
+					cmgen.SetCertificateRequestNamespace("ns1"),
+					cmgen.SetCertificateRequestCSR(validCSR),
+					cmgen.SetCertificateRequestDuration(&metav1.Duration{Duration: 24 * time.Hour}),
+					cmgen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+						Name:  "issuer1",
+						Group: cfsslissuerapi.GroupVersion.Group,
+						Kind:  "Issuer",
+					}),
+					cmgen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+						Type:   cmapi.CertificateRequestConditionApproved,
+						Status: cmmeta.ConditionTrue,
+					}),
+					cmgen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+						Type:   cmapi.CertificateRequestConditionReady,
+						Status: cmmeta.ConditionUnknown,
+					}),
+				),
+			},
+			issuerObjects: []client.Object{&cfsslissuerapi.Issuer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "issuer1",
+					Namespace: "ns1",
+				},
+				Spec: cfsslissuerapi.IssuerSpec{
+					AuthSecretName: "issuer1-credentials",
+					MaxDuration:    &metav1.Duration{Duration: time.Hour},
+				},
+				Status: cfsslissuerapi.IssuerStatus{
+					Conditions: []metav1.Condition{
+						{
+							Type:   cfsslissuerapi.IssuerConditionReady,
+							Status: metav1.ConditionTrue,
+						},
+					},
+				},
+			},
+			},
+			secretObjects: []client.Object{&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "issuer1-credentials",
+					Namespace: "ns1",
+				},
+			},
+			},
+			signerBuilder: func(*cfsslissuerapi.IssuerSpec, *signer.IssuerData) (signer.Signer, error) {
+				return &fakeSigner{cert: certValidFor24h}, nil
 			},
 			expectedReadyConditionStatus: cmmeta.ConditionTrue,
 			expectedReadyConditionReason: cmapi.CertificateRequestReasonIssued,
 			expectedFailureTime:          nil,
-			expectedCertificate:          []byte("fake signed certificate"),
+			expectedCertificate:          certValidFor24h,
+			expectedEvents: []string{
+				"Warning DurationMismatch Issued certificate is valid for 24h0m0s, but 1h0m0s (capped from 24h0m0s by the maxDuration of the issuer) was requested",
+			},
 		},
 		"success-cluster-issuer": {
 			name: types.NamespacedName{Namespace: "ns1", Name: "cr1"},
@@ -122,6 +1434,7 @@ func TestCertificateRequestReconcile(t *testing.T) {
 				cmgen.CertificateRequest(
 					"cr1",
 					cmgen.SetCertificateRequestNamespace("ns1"),
+					cmgen.SetCertificateRequestCSR(validCSR),
 					cmgen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
 						Name:  "clusterissuer1",
 						Group: cfsslissuerapi.GroupVersion.Group,
@@ -146,10 +1459,10 @@ func TestCertificateRequestReconcile(t *testing.T) {
 						AuthSecretName: "clusterissuer1-credentials",
 					},
 					Status: cfsslissuerapi.IssuerStatus{
-						Conditions: []cfsslissuerapi.IssuerCondition{
+						Conditions: []metav1.Condition{
 							{
 								Type:   cfsslissuerapi.IssuerConditionReady,
-								Status: cfsslissuerapi.ConditionTrue,
+								Status: metav1.ConditionTrue,
 							},
 						},
 					},
@@ -161,14 +1474,14 @@ func TestCertificateRequestReconcile(t *testing.T) {
 					Namespace: "kube-system",
 				},
 			}},
-			signerBuilder: func(*cfsslissuerapi.IssuerSpec, map[string][]byte) (signer.Signer, error) {
+			signerBuilder: func(*cfsslissuerapi.IssuerSpec, *signer.IssuerData) (signer.Signer, error) {
 				return &fakeSigner{}, nil
 			},
 			clusterResourceNamespace:     "kube-system",
 			expectedReadyConditionStatus: cmmeta.ConditionTrue,
 			expectedReadyConditionReason: cmapi.CertificateRequestReasonIssued,
 			expectedFailureTime:          nil,
-			expectedCertificate:          []byte("fake signed certificate"),
+			expectedCertificate:          validCertificate,
 		},
 		"certificaterequest-not-found": {
 			name: types.NamespacedName{Namespace: "ns1", Name: "cr1"},
@@ -179,6 +1492,7 @@ func TestCertificateRequestReconcile(t *testing.T) {
 				cmgen.CertificateRequest(
 					"cr1",
 					cmgen.SetCertificateRequestNamespace("ns1"),
+					cmgen.SetCertificateRequestCSR(validCSR),
 					cmgen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
 						Name:  "issuer1",
 						Group: "foreign-issuer.example.com",
@@ -192,6 +1506,7 @@ func TestCertificateRequestReconcile(t *testing.T) {
 				cmgen.CertificateRequest(
 					"cr1",
 					cmgen.SetCertificateRequestNamespace("ns1"),
+					cmgen.SetCertificateRequestCSR(validCSR),
 					cmgen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
 						Name:  "issuer1",
 						Group: cfsslissuerapi.GroupVersion.Group,
@@ -214,6 +1529,7 @@ func TestCertificateRequestReconcile(t *testing.T) {
 				cmgen.CertificateRequest(
 					"cr1",
 					cmgen.SetCertificateRequestNamespace("ns1"),
+					cmgen.SetCertificateRequestCSR(validCSR),
 					cmgen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
 						Name:  "issuer1",
 						Group: cfsslissuerapi.GroupVersion.Group,
@@ -234,6 +1550,7 @@ func TestCertificateRequestReconcile(t *testing.T) {
 				cmgen.CertificateRequest(
 					"cr1",
 					cmgen.SetCertificateRequestNamespace("ns1"),
+					cmgen.SetCertificateRequestCSR(validCSR),
 					cmgen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
 						Name:  "issuer1",
 						Group: cfsslissuerapi.GroupVersion.Group,
@@ -258,6 +1575,7 @@ func TestCertificateRequestReconcile(t *testing.T) {
 				cmgen.CertificateRequest(
 					"cr1",
 					cmgen.SetCertificateRequestNamespace("ns1"),
+					cmgen.SetCertificateRequestCSR(validCSR),
 					cmgen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
 						Name:  "issuer1",
 						Group: cfsslissuerapi.GroupVersion.Group,
@@ -283,6 +1601,7 @@ func TestCertificateRequestReconcile(t *testing.T) {
 				cmgen.CertificateRequest(
 					"cr1",
 					cmgen.SetCertificateRequestNamespace("ns1"),
+					cmgen.SetCertificateRequestCSR(validCSR),
 					cmgen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
 						Name:  "clusterissuer1",
 						Group: cfsslissuerapi.GroupVersion.Group,
@@ -308,6 +1627,7 @@ func TestCertificateRequestReconcile(t *testing.T) {
 				cmgen.CertificateRequest(
 					"cr1",
 					cmgen.SetCertificateRequestNamespace("ns1"),
+					cmgen.SetCertificateRequestCSR(validCSR),
 					cmgen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
 						Name:  "issuer1",
 						Group: cfsslissuerapi.GroupVersion.Group,
@@ -330,16 +1650,15 @@ func TestCertificateRequestReconcile(t *testing.T) {
 						Namespace: "ns1",
 					},
 					Status: cfsslissuerapi.IssuerStatus{
-						Conditions: []cfsslissuerapi.IssuerCondition{
+						Conditions: []metav1.Condition{
 							{
 								Type:   cfsslissuerapi.IssuerConditionReady,
-								Status: cfsslissuerapi.ConditionFalse,
+								Status: metav1.ConditionFalse,
 							},
 						},
 					},
 				},
 			},
-			expectedError:                errIssuerNotReady,
 			expectedReadyConditionStatus: cmmeta.ConditionFalse,
 			expectedReadyConditionReason: cmapi.CertificateRequestReasonPending,
 		},
@@ -349,6 +1668,7 @@ func TestCertificateRequestReconcile(t *testing.T) {
 				cmgen.CertificateRequest(
 					"cr1",
 					cmgen.SetCertificateRequestNamespace("ns1"),
+					cmgen.SetCertificateRequestCSR(validCSR),
 					cmgen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
 						Name:  "issuer1",
 						Group: cfsslissuerapi.GroupVersion.Group,
@@ -374,10 +1694,10 @@ func TestCertificateRequestReconcile(t *testing.T) {
 						AuthSecretName: "issuer1-credentials",
 					},
 					Status: cfsslissuerapi.IssuerStatus{
-						Conditions: []cfsslissuerapi.IssuerCondition{
+						Conditions: []metav1.Condition{
 							{
 								Type:   cfsslissuerapi.IssuerConditionReady,
-								Status: cfsslissuerapi.ConditionTrue,
+								Status: metav1.ConditionTrue,
 							},
 						},
 					},
@@ -393,6 +1713,7 @@ func TestCertificateRequestReconcile(t *testing.T) {
 				cmgen.CertificateRequest(
 					"cr1",
 					cmgen.SetCertificateRequestNamespace("ns1"),
+					cmgen.SetCertificateRequestCSR(validCSR),
 					cmgen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
 						Name:  "issuer1",
 						Group: cfsslissuerapi.GroupVersion.Group,
@@ -426,16 +1747,16 @@ func TestCertificateRequestReconcile(t *testing.T) {
 						AuthSecretName: "issuer1-credentials",
 					},
 					Status: cfsslissuerapi.IssuerStatus{
-						Conditions: []cfsslissuerapi.IssuerCondition{
+						Conditions: []metav1.Condition{
 							{
 								Type:   cfsslissuerapi.IssuerConditionReady,
-								Status: cfsslissuerapi.ConditionTrue,
+								Status: metav1.ConditionTrue,
 							},
 						},
 					},
 				},
 			},
-			signerBuilder: func(*cfsslissuerapi.IssuerSpec, map[string][]byte) (signer.Signer, error) {
+			signerBuilder: func(*cfsslissuerapi.IssuerSpec, *signer.IssuerData) (signer.Signer, error) {
 				return nil, errors.New("simulated signer builder error")
 			},
 			expectedError:                errSignerBuilder,
@@ -448,6 +1769,7 @@ func TestCertificateRequestReconcile(t *testing.T) {
 				cmgen.CertificateRequest(
 					"cr1",
 					cmgen.SetCertificateRequestNamespace("ns1"),
+					cmgen.SetCertificateRequestCSR(validCSR),
 					cmgen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
 						Name:  "issuer1",
 						Group: cfsslissuerapi.GroupVersion.Group,
@@ -481,28 +1803,137 @@ func TestCertificateRequestReconcile(t *testing.T) {
 						AuthSecretName: "issuer1-credentials",
 					},
 					Status: cfsslissuerapi.IssuerStatus{
-						Conditions: []cfsslissuerapi.IssuerCondition{
+						Conditions: []metav1.Condition{
 							{
 								Type:   cfsslissuerapi.IssuerConditionReady,
-								Status: cfsslissuerapi.ConditionTrue,
+								Status: metav1.ConditionTrue,
 							},
 						},
 					},
 				},
 			},
-			signerBuilder: func(*cfsslissuerapi.IssuerSpec, map[string][]byte) (signer.Signer, error) {
+			signerBuilder: func(*cfsslissuerapi.IssuerSpec, *signer.IssuerData) (signer.Signer, error) {
 				return &fakeSigner{errSign: errors.New("simulated sign error")}, nil
 			},
 			expectedError:                errSignerSign,
 			expectedReadyConditionStatus: cmmeta.ConditionFalse,
 			expectedReadyConditionReason: cmapi.CertificateRequestReasonPending,
 		},
+		"signer-error-permanent": {
+			name: types.NamespacedName{Namespace: "ns1", Name: "cr1"},
+			crObjects: []client.Object{
+				cmgen.CertificateRequest(
+					"cr1",
+					cmgen.SetCertificateRequestNamespace("ns1"),
+					cmgen.SetCertificateRequestCSR(validCSR),
+					cmgen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+						Name:  "issuer1",
+						Group: cfsslissuerapi.GroupVersion.Group,
+						Kind:  "Issuer",
+					}),
+					cmgen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+						Type:   cmapi.CertificateRequestConditionApproved,
+						Status: cmmeta.ConditionTrue,
+					}),
+					cmgen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+						Type:   cmapi.CertificateRequestConditionReady,
+						Status: cmmeta.ConditionUnknown,
+					}),
+				),
+			},
+			secretObjects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "issuer1-credentials",
+						Namespace: "ns1",
+					},
+				},
+			},
+			issuerObjects: []client.Object{
+				&cfsslissuerapi.Issuer{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "issuer1",
+						Namespace: "ns1",
+					},
+					Spec: cfsslissuerapi.IssuerSpec{
+						AuthSecretName: "issuer1-credentials",
+					},
+					Status: cfsslissuerapi.IssuerStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:   cfsslissuerapi.IssuerConditionReady,
+								Status: metav1.ConditionTrue,
+							},
+						},
+					},
+				},
+			},
+			signerBuilder: func(*cfsslissuerapi.IssuerSpec, *signer.IssuerData) (signer.Signer, error) {
+				return &fakeSigner{errSign: fmt.Errorf("%w: simulated policy violation", signer.ErrRequestRejected)}, nil
+			},
+			expectedReadyConditionStatus: cmmeta.ConditionFalse,
+			expectedReadyConditionReason: cmapi.CertificateRequestReasonFailed,
+			expectedFailureTime:          &nowMetaTime,
+		},
+		"invalid-csr": {
+			name: types.NamespacedName{Namespace: "ns1", Name: "cr1"},
+			crObjects: []client.Object{
+				cmgen.CertificateRequest(
+					"cr1",
+					cmgen.SetCertificateRequestNamespace("ns1"),
+					cmgen.SetCertificateRequestCSR([]byte("not a csr")),
+					cmgen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+						Name:  "issuer1",
+						Group: cfsslissuerapi.GroupVersion.Group,
+						Kind:  "Issuer",
+					}),
+					cmgen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+						Type:   cmapi.CertificateRequestConditionApproved,
+						Status: cmmeta.ConditionTrue,
+					}),
+					cmgen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+						Type:   cmapi.CertificateRequestConditionReady,
+						Status: cmmeta.ConditionUnknown,
+					}),
+				),
+			},
+			expectedFailureTime:          &nowMetaTime,
+			expectedReadyConditionStatus: cmmeta.ConditionFalse,
+			expectedReadyConditionReason: cmapi.CertificateRequestReasonFailed,
+		},
+		"invalid-csr-signature": {
+			name: types.NamespacedName{Namespace: "ns1", Name: "cr1"},
+			crObjects: []client.Object{
+				cmgen.CertificateRequest(
+					"cr1",
+					cmgen.SetCertificateRequestNamespace("ns1"),
+					cmgen.SetCertificateRequestCSR(mustTamperCSRSignature(validCSR)),
+					cmgen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+						Name:  "issuer1",
+						Group: cfsslissuerapi.GroupVersion.Group,
+						Kind:  "Issuer",
+					}),
+					cmgen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+						Type:   cmapi.CertificateRequestConditionApproved,
+						Status: cmmeta.ConditionTrue,
+					}),
+					cmgen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+						Type:   cmapi.CertificateRequestConditionReady,
+						Status: cmmeta.ConditionUnknown,
+					}),
+				),
+			},
+			expectedFailureTime:          &nowMetaTime,
+			expectedReadyConditionStatus: cmmeta.ConditionFalse,
+			expectedReadyConditionReason: cmapi.CertificateRequestReasonFailed,
+		},
 		"request-not-approved": {
 			name: types.NamespacedName{Namespace: "ns1", Name: "cr1"},
 			crObjects: []client.Object{
 				cmgen.CertificateRequest(
 					"cr1",
 					cmgen.SetCertificateRequestNamespace("ns1"),
+					cmgen.SetCertificateRequestCSR(validCSR),
 					cmgen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
 						Name:  "issuer1",
 						Group: cfsslissuerapi.GroupVersion.Group,
@@ -532,16 +1963,16 @@ func TestCertificateRequestReconcile(t *testing.T) {
 						AuthSecretName: "issuer1-credentials",
 					},
 					Status: cfsslissuerapi.IssuerStatus{
-						Conditions: []cfsslissuerapi.IssuerCondition{
+						Conditions: []metav1.Condition{
 							{
 								Type:   cfsslissuerapi.IssuerConditionReady,
-								Status: cfsslissuerapi.ConditionTrue,
+								Status: metav1.ConditionTrue,
 							},
 						},
 					},
 				},
 			},
-			signerBuilder: func(*cfsslissuerapi.IssuerSpec, map[string][]byte) (signer.Signer, error) {
+			signerBuilder: func(*cfsslissuerapi.IssuerSpec, *signer.IssuerData) (signer.Signer, error) {
 				return &fakeSigner{}, nil
 			},
 			expectedFailureTime: nil,
@@ -553,6 +1984,7 @@ func TestCertificateRequestReconcile(t *testing.T) {
 				cmgen.CertificateRequest(
 					"cr1",
 					cmgen.SetCertificateRequestNamespace("ns1"),
+					cmgen.SetCertificateRequestCSR(validCSR),
 					cmgen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
 						Name:  "issuer1",
 						Group: cfsslissuerapi.GroupVersion.Group,
@@ -578,10 +2010,10 @@ func TestCertificateRequestReconcile(t *testing.T) {
 						AuthSecretName: "issuer1-credentials",
 					},
 					Status: cfsslissuerapi.IssuerStatus{
-						Conditions: []cfsslissuerapi.IssuerCondition{
+						Conditions: []metav1.Condition{
 							{
 								Type:   cfsslissuerapi.IssuerConditionReady,
-								Status: cfsslissuerapi.ConditionTrue,
+								Status: metav1.ConditionTrue,
 							},
 						},
 					},
@@ -595,7 +2027,7 @@ func TestCertificateRequestReconcile(t *testing.T) {
 					},
 				},
 			},
-			signerBuilder: func(*cfsslissuerapi.IssuerSpec, map[string][]byte) (signer.Signer, error) {
+			signerBuilder: func(*cfsslissuerapi.IssuerSpec, *signer.IssuerData) (signer.Signer, error) {
 				return &fakeSigner{}, nil
 			},
 			expectedCertificate:          nil,
@@ -681,6 +2113,20 @@ func TestCertificateRequestReconcile(t *testing.T) {
 			// set without also having first added and updated the Ready
 			// condition.
 			assert.Equal(t, tc.expectedCertificate, crAfter.Status.Certificate)
+			if tc.expectedCertificate != nil {
+				expectedLabel := tc.expectedLabel
+				if expectedLabel == "" {
+					expectedLabel = testSignerLabel
+				}
+				assert.Equal(t, expectedLabel, crAfter.Annotations[cfsslissuerapi.LabelAnnotationKey], "signer label was not recorded")
+			} else {
+				assert.Empty(t, crAfter.Annotations[cfsslissuerapi.LabelAnnotationKey], "signer label recorded without a certificate")
+			}
+			if tc.expectedRolloutStatus != nil {
+				var issuerAfter cfsslissuerapi.Issuer
+				require.NoError(t, fakeClient.Get(context.TODO(), client.ObjectKeyFromObject(tc.issuerObjects[0]), &issuerAfter))
+				assert.Equal(t, tc.expectedRolloutStatus, issuerAfter.Status.Rollout, "unexpected rollout status")
+			}
 
 			if !apiequality.Semantic.DeepEqual(tc.expectedFailureTime, crAfter.Status.FailureTime) {
 				assert.Equal(t, tc.expectedFailureTime, crAfter.Status.FailureTime)
@@ -723,7 +2169,7 @@ func TestCertificateRequestReconcile(t *testing.T) {
 				// Each Reconcile should only emit a single Event
 				assert.Equal(
 					t,
-					[]string{fmt.Sprintf("%s %s %s", expectedEventType, cfsslissuerapi.EventReasonCertificateRequestReconciler, eventMessage)},
+					append(tc.expectedEvents, fmt.Sprintf("%s %s %s", expectedEventType, cfsslissuerapi.EventReasonCertificateRequestReconciler, eventMessage)),
 					actualEvents,
 					"expected a single event matching the condition",
 				)
@@ -752,3 +2198,178 @@ func verifyCertificateRequestReadyCondition(t *testing.T, status cmmeta.Conditio
 	assert.Contains(t, validReasons, reason, "unexpected condition reason")
 	assert.Equal(t, reason, condition.Reason, "unexpected condition reason")
 }
+
+func TestCertificateRequestsForIssuer(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, cfsslissuerapi.AddToScheme(scheme))
+	require.NoError(t, cmapi.AddToScheme(scheme))
+	newCR := func(namespace, name, kind, group string) client.Object {
+		return cmgen.CertificateRequest(name,
+			cmgen.SetCertificateRequestNamespace(namespace),
+			cmgen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+				Name:  "issuer1",
+				Group: group,
+				Kind:  kind,
+			}),
+		)
+	}
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			newCR("ns1", "cr1", "Issuer", cfsslissuerapi.GroupVersion.Group),
+			newCR("ns1", "cr2", "ClusterIssuer", cfsslissuerapi.GroupVersion.Group),
+			newCR("ns1", "cr3", "Issuer", "foreign-issuer.example.com"),
+			newCR("ns2", "cr4", "Issuer", cfsslissuerapi.GroupVersion.Group),
+			newCR("ns2", "cr5", "ClusterIssuer", cfsslissuerapi.GroupVersion.Group),
+		).
+		WithIndex(&cmapi.CertificateRequest{}, issuerRefField, issuerRef).
+		Build()
+	controller := CertificateRequestReconciler{
+		Client: fakeClient,
+		Scheme: scheme,
+	}
+
+	assert.Equal(t,
+		[]reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: "ns1", Name: "cr1"}}},
+		controller.certificateRequestsForIssuer(context.TODO(), &cfsslissuerapi.Issuer{ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "issuer1"}}),
+	)
+	assert.ElementsMatch(t,
+		[]reconcile.Request{
+			{NamespacedName: types.NamespacedName{Namespace: "ns1", Name: "cr2"}},
+			{NamespacedName: types.NamespacedName{Namespace: "ns2", Name: "cr5"}},
+		},
+		controller.certificateRequestsForIssuer(context.TODO(), &cfsslissuerapi.ClusterIssuer{ObjectMeta: metav1.ObjectMeta{Name: "issuer1"}}),
+	)
+	assert.Empty(t, controller.certificateRequestsForIssuer(context.TODO(), &cfsslissuerapi.Issuer{ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "issuer2"}}))
+}
+
+func TestIssuerBecameReady(t *testing.T) {
+	issuerWithReady := func(status metav1.ConditionStatus) *cfsslissuerapi.Issuer {
+		issuer := &cfsslissuerapi.Issuer{}
+		if status != "" {
+			issuer.Status.Conditions = []metav1.Condition{
+				{Type: cfsslissuerapi.IssuerConditionReady, Status: status},
+			}
+		}
+		return issuer
+	}
+	tests := map[string]struct {
+		old, new metav1.ConditionStatus
+		expected bool
+	}{
+		"became-ready":     {old: metav1.ConditionFalse, new: metav1.ConditionTrue, expected: true},
+		"first-ready":      {old: "", new: metav1.ConditionTrue, expected: true},
+		"still-ready":      {old: metav1.ConditionTrue, new: metav1.ConditionTrue, expected: false},
+		"became-not-ready": {old: metav1.ConditionTrue, new: metav1.ConditionFalse, expected: false},
+		"still-not-ready":  {old: metav1.ConditionUnknown, new: metav1.ConditionFalse, expected: false},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, issuerBecameReady.Update(event.UpdateEvent{
+				ObjectOld: issuerWithReady(tc.old),
+				ObjectNew: issuerWithReady(tc.new),
+			}))
+		})
+	}
+}
+
+func TestRolloutLabel(t *testing.T) {
+	issuerSpec := &cfsslissuerapi.IssuerSpec{
+		Label: "primary-label",
+		Rollout: &cfsslissuerapi.LabelRollout{
+			Label:  "secondary-label",
+			Weight: 30,
+		},
+	}
+	newCR := func(name, certificate string) *cmapi.CertificateRequest {
+		cr := cmgen.CertificateRequest(name, cmgen.SetCertificateRequestNamespace("ns1"))
+		if certificate != "" {
+			cr.OwnerReferences = []metav1.OwnerReference{
+				*metav1.NewControllerRef(&cmapi.Certificate{ObjectMeta: metav1.ObjectMeta{Name: certificate}}, cmapi.SchemeGroupVersion.WithKind("Certificate")),
+			}
+		}
+		return cr
+	}
+
+	// All CertificateRequests of a Certificate get the same label
+	for i := 0; i < 20; i++ {
+		certificate := fmt.Sprintf("crt%d", i)
+		assert.Equal(t,
+			rolloutLabel(newCR(certificate+"-1", certificate), issuerSpec),
+			rolloutLabel(newCR(certificate+"-2", certificate), issuerSpec),
+		)
+	}
+
+	// The share of Certificates getting the secondary label follows the weight
+	secondary := 0
+	for i := 0; i < 1000; i++ {
+		if rolloutLabel(newCR(fmt.Sprintf("cr%d", i), ""), issuerSpec) == "secondary-label" {
+			secondary++
+		}
+	}
+	assert.InDelta(t, 300, secondary, 50)
+
+	issuerSpec.Rollout = nil
+	assert.Equal(t, "primary-label", rolloutLabel(newCR("cr1", "crt1"), issuerSpec))
+}
+
+func TestCountRolloutRequest(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, cfsslissuerapi.AddToScheme(scheme))
+	cfsslIssuer := &cfsslissuerapi.Issuer{
+		ObjectMeta: metav1.ObjectMeta{Name: "issuer1", Namespace: "ns1"},
+		Spec: cfsslissuerapi.IssuerSpec{
+			Label: "primary-label",
+			Rollout: &cfsslissuerapi.LabelRollout{
+				Label:  "secondary-label",
+				Weight: 30,
+			},
+		},
+	}
+	// Only the rollout status is sent, the rest of it is left to the Issuer
+	// controller
+	var patches []map[string]map[string]interface{}
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(cfsslIssuer).
+		WithStatusSubresource(cfsslIssuer).
+		WithInterceptorFuncs(interceptor.Funcs{
+			SubResourcePatch: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+				data, err := patch.Data(obj)
+				require.NoError(t, err)
+				var p map[string]map[string]interface{}
+				require.NoError(t, json.Unmarshal(data, &p))
+				patches = append(patches, p)
+				return c.SubResource(subResourceName).Patch(ctx, obj, patch, opts...)
+			},
+		}).
+		Build()
+	ctx := context.TODO()
+
+	stale := &cfsslissuerapi.Issuer{}
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(cfsslIssuer), stale))
+
+	// The Issuer controller updates the status in the meantime
+	current := stale.DeepCopy()
+	current.Status.AcceptedKey = "key1"
+	require.NoError(t, fakeClient.Status().Update(ctx, current))
+
+	require.NoError(t, countRolloutRequest(ctx, fakeClient, stale, "secondary-label"))
+	require.NoError(t, countRolloutRequest(ctx, fakeClient, stale, "primary-label"))
+	require.NoError(t, countRolloutRequest(ctx, fakeClient, stale, "secondary-label"))
+
+	got := &cfsslissuerapi.Issuer{}
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(cfsslIssuer), got))
+	assert.Equal(t, "key1", got.Status.AcceptedKey)
+	assert.Equal(t, &cfsslissuerapi.RolloutStatus{
+		Label:             "secondary-label",
+		Weight:            30,
+		PrimaryRequests:   1,
+		SecondaryRequests: 2,
+	}, got.Status.Rollout)
+	require.Len(t, patches, 3)
+	for _, p := range patches {
+		assert.Len(t, p["status"], 1)
+		assert.Contains(t, p["status"], "rollout")
+	}
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2021 The Wikimedia Foundation, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cfsslissuerapi "gerrit.wikimedia.org/r/operations/software/cfssl-issuer/api/v1alpha1"
+)
+
+var (
+	errGetClientCertificateSecret  = errors.New("failed to get Secret containing the client certificate")
+	errClientCertificateKeyMissing = errors.New("Secret does not contain required fields \"tls.crt\" and \"tls.key\"")
+)
+
+// getClientCertificateSecretData returns the data of the client certificate
+// Secret referenced by an {Cluster}Issuer, or nil if there is none.
+func getClientCertificateSecretData(ctx context.Context, c client.Client, issuerSpec *cfsslissuerapi.IssuerSpec, namespace string) (map[string][]byte, error) {
+	if issuerSpec.ClientCertificateSecretName == "" {
+		return nil, nil
+	}
+
+	secretName := types.NamespacedName{
+		Name:      issuerSpec.ClientCertificateSecretName,
+		Namespace: namespace,
+	}
+	var secret corev1.Secret
+	if err := c.Get(ctx, secretName, &secret); err != nil {
+		return nil, fmt.Errorf("%w, secret name: %s, reason: %v", errGetClientCertificateSecret, secretName, err)
+	}
+	if len(secret.Data[corev1.TLSCertKey]) == 0 || len(secret.Data[corev1.TLSPrivateKeyKey]) == 0 {
+		return nil, fmt.Errorf("%w, secret name: %s", errClientCertificateKeyMissing, secretName)
+	}
+	return secret.Data, nil
+}
@@ -21,15 +21,23 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	cfsslissuerapi "gerrit.wikimedia.org/r/operations/software/cfssl-issuer/api/v1alpha1"
 	"gerrit.wikimedia.org/r/operations/software/cfssl-issuer/internal/issuer/signer"
@@ -38,6 +46,12 @@ import (
 
 const (
 	defaultHealthCheckInterval = time.Minute
+
+	// Used if the IssuerSpec does not specify a CA expiry warning window.
+	defaultCAExpiryWarning = 30 * 24 * time.Hour
+
+	// Field index of the issuers by the name of their auth Secret.
+	authSecretNameField = "spec.authSecretName"
 )
 
 var (
@@ -54,13 +68,17 @@ type IssuerReconciler struct {
 	Scheme                   *runtime.Scheme
 	ClusterResourceNamespace string
 	HealthCheckerBuilder     signer.HealthCheckerBuilder
+	Clock                    clock.Clock
 	recorder                 record.EventRecorder
 }
 
 // +kubebuilder:rbac:groups=cfssl-issuer.wikimedia.org,resources=issuers;clusterissuers,verbs=get;list;watch
 // +kubebuilder:rbac:groups=cfssl-issuer.wikimedia.org,resources=issuers/status;clusterissuers/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups=cert-manager.io,resources=certificates,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups=cert-manager.io,resources=certificates/status,verbs=get;patch
 
 func (r *IssuerReconciler) newIssuer() (client.Object, error) {
 	issuerGVK := cfsslissuerapi.GroupVersion.WithKind(r.Kind)
@@ -84,19 +102,28 @@ func (r *IssuerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res
 			return ctrl.Result{}, fmt.Errorf("unexpected get error: %v", err)
 		}
 		log.Info("Not found. Ignoring.")
+		deleteIssuerMetrics(r.Kind, req.NamespacedName)
+		signer.ForgetIssuer(issuerKey(r.Kind, req.NamespacedName))
 		return ctrl.Result{}, nil
 	}
 
+	// The status is patched, so that the rollout counters maintained by the
+	// CertificateRequest controller are not overwritten
+	original := issuer.DeepCopyObject().(client.Object)
 	issuerSpec, issuerStatus, err := issuerutil.GetSpecAndStatus(issuer)
 	if err != nil {
 		log.Error(err, "Unexpected error while getting issuer spec and status. Not retrying.")
 		return ctrl.Result{}, nil
 	}
+	issuerutil.ConvertConditions(issuerStatus, metav1.NewTime(r.Clock.Now()))
 
 	// report gives feedback by updating the Ready Condition of the {Cluster}Issuer
 	// For added visibility we also log a message and create a Kubernetes Event.
-	report := func(conditionStatus cfsslissuerapi.ConditionStatus, message string, err error) {
+	report := func(conditionStatus metav1.ConditionStatus, reason cfsslissuerapi.IssuerConditionReason, message string, err error) {
 		eventType := corev1.EventTypeNormal
+		if conditionStatus == metav1.ConditionFalse {
+			eventType = corev1.EventTypeWarning
+		}
 		if err != nil {
 			log.Error(err, message)
 			eventType = corev1.EventTypeWarning
@@ -107,28 +134,38 @@ func (r *IssuerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res
 		r.recorder.Event(
 			issuer,
 			eventType,
-			cfsslissuerapi.EventReasonIssuerReconciler,
+			string(reason),
 			message,
 		)
-		issuerutil.SetReadyCondition(issuerStatus, conditionStatus, cfsslissuerapi.EventReasonIssuerReconciler, message)
+		issuerutil.SetReadyCondition(issuerStatus, issuer.GetGeneration(), conditionStatus, reason, message)
 	}
 
+	// Set once the result of a health check is in the metrics
+	var metricsRecorded bool
+
 	// Always attempt to update the Ready condition
 	defer func() {
 		if err != nil {
-			report(cfsslissuerapi.ConditionFalse, "Temporary error. Retrying", err)
+			report(metav1.ConditionFalse, issuerReason(err), "Temporary error. Retrying", err)
+			if !metricsRecorded {
+				recordFailedHealthCheckMetrics(r.Kind, req.NamespacedName, issuerSpec, issuerReason(err))
+			}
 		}
-		if updateErr := r.Status().Update(ctx, issuer); updateErr != nil {
+		issuerStatus.ObservedGeneration = issuer.GetGeneration()
+		if updateErr := r.Status().Patch(ctx, issuer, client.MergeFrom(original)); updateErr != nil {
 			err = utilerrors.NewAggregate([]error{err, updateErr})
 			result = ctrl.Result{}
 		}
 	}()
 
 	if ready := issuerutil.GetReadyCondition(issuerStatus); ready == nil {
-		report(cfsslissuerapi.ConditionUnknown, "First seen", nil)
-		return ctrl.Result{}, nil
+		report(metav1.ConditionUnknown, cfsslissuerapi.IssuerReasonPending, "First seen", nil)
+		// The status update does not trigger another reconcile
+		return ctrl.Result{Requeue: true}, nil
 	}
 
+	resetRolloutStatus(issuerSpec, issuerStatus)
+
 	secretName := types.NamespacedName{
 		Name: issuerSpec.AuthSecretName,
 	}
@@ -151,26 +188,344 @@ func (r *IssuerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res
 		return ctrl.Result{}, fmt.Errorf("%w, secret name: %s", errAuthSecretKeyMissing, secretName)
 	}
 
-	checker, err := r.HealthCheckerBuilder(issuerSpec, secret.Data)
+	caBundle, err := getCABundle(ctx, r.Client, issuerSpec, secretName.Namespace)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	clientCertData, err := getClientCertificateSecretData(ctx, r.Client, issuerSpec, secretName.Namespace)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	checker, err := r.HealthCheckerBuilder(issuerSpec, &signer.IssuerData{
+		IssuerKey:                   issuerKey(r.Kind, req.NamespacedName),
+		AuthSecretData:              secret.Data,
+		CABundle:                    caBundle,
+		ClientCertificateSecretData: clientCertData,
+	})
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("%w: %w", errHealthCheckerBuilder, err)
+	}
+
+	interval, failureThreshold, successThreshold := healthCheckSettings(issuerSpec)
+	checkResult, err := checker.Check(ctx)
+	if err != nil {
+		err = fmt.Errorf("%w: %w", errHealthCheckerCheck, err)
+	}
+	healthCheck := r.recordHealthCheck(issuerStatus, err == nil)
+	if checkResult != nil {
+		reason := cfsslissuerapi.IssuerReasonHealthy
+		if err != nil {
+			reason = issuerReason(err)
+		}
+		recordHealthCheckMetrics(r.Kind, req.NamespacedName, issuerSpec, checkResult, reason)
+		metricsRecorded = true
+		issuerStatus.Endpoints = checkResult.Endpoints
+		issuerStatus.Labels = checkResult.Labels
+		// The accepted key is only known once a request was authenticated,
+		// so keep the one observed before a restart of the controller
+		if checkResult.AcceptedKey != "" || errors.Is(err, signer.ErrAuthenticationFailed) {
+			issuerStatus.AcceptedKey = checkResult.AcceptedKey
+		}
+		// A CA reported along with an error may have failed the pin check,
+		// so it is not trusted.
+		if err == nil && checkResult.CA != nil {
+			r.handleCARotation(ctx, issuer, issuerSpec, issuerStatus, checkResult.CA)
+			issuerStatus.CA = checkResult.CA
+			r.setCAExpiringSoonCondition(issuer.GetGeneration(), issuerSpec, issuerStatus)
+		}
+	} else if err != nil {
+		recordFailedHealthCheckMetrics(r.Kind, req.NamespacedName, issuerSpec, issuerReason(err))
+		metricsRecorded = true
+	}
+	// Failures to re-issue Certificates are reported by their own condition,
+	// so they do not keep the issuer from signing
+	if err == nil && r.reissuePendingCertificates(ctx, issuer, issuerSpec, issuerStatus) && reissueBatchInterval < interval {
+		interval = reissueBatchInterval
+	}
+	r.setDegradedCondition(issuer.GetGeneration(), issuerStatus, err)
+	r.setAuthVerifiedCondition(issuer.GetGeneration(), issuerSpec, issuerStatus, checkResult, err)
 	if err != nil {
-		return ctrl.Result{}, fmt.Errorf("%w: %v", errHealthCheckerBuilder, err)
+		// A ready issuer tolerates failures up to the threshold, but not an
+		// unexpected signer certificate
+		if issuerutil.IsReady(issuerStatus) && healthCheck.ConsecutiveFailures < failureThreshold && !errors.Is(err, signer.ErrCAPinMismatch) {
+			report(metav1.ConditionTrue, cfsslissuerapi.IssuerReasonFailureTolerated, fmt.Sprintf("Health check failed (%d of %d consecutive failures tolerated): %v",
+				healthCheck.ConsecutiveFailures, failureThreshold-1, err), nil)
+			return ctrl.Result{RequeueAfter: interval}, nil
+		}
+		return ctrl.Result{}, err
 	}
 
-	if err := checker.Check(); err != nil {
-		return ctrl.Result{}, fmt.Errorf("%w: %v", errHealthCheckerCheck, err)
+	// An issuer which is not ready recovers once the threshold is reached
+	if ready := issuerutil.GetReadyCondition(issuerStatus); ready.Status == metav1.ConditionFalse && healthCheck.ConsecutiveSuccesses < successThreshold {
+		report(metav1.ConditionFalse, cfsslissuerapi.IssuerReasonRecovering, fmt.Sprintf("Health check succeeded (%d of %d consecutive successes required to recover)",
+			healthCheck.ConsecutiveSuccesses, successThreshold), nil)
+		return ctrl.Result{RequeueAfter: interval}, nil
 	}
 
-	report(cfsslissuerapi.ConditionTrue, "Success", nil)
-	return ctrl.Result{RequeueAfter: defaultHealthCheckInterval}, nil
+	report(metav1.ConditionTrue, cfsslissuerapi.IssuerReasonHealthy, "Success", nil)
+	return ctrl.Result{RequeueAfter: interval}, nil
+}
+
+// issuerKey identifies an issuer in the state kept by the signer package.
+func issuerKey(kind string, name types.NamespacedName) string {
+	return kind + "/" + name.String()
 }
 
+// issuerReason returns the reason of the Ready condition of an issuer which
+// could not be reconciled because of err.
+func issuerReason(err error) cfsslissuerapi.IssuerConditionReason {
+	switch {
+	case errors.Is(err, errGetAuthSecret):
+		return cfsslissuerapi.IssuerReasonAuthSecretUnavailable
+	case errors.Is(err, errAuthSecretKeyMissing):
+		return cfsslissuerapi.IssuerReasonAuthSecretKeyMissing
+	case errors.Is(err, errGetCABundle), errors.Is(err, errCABundleKeyMissing):
+		return cfsslissuerapi.IssuerReasonCABundleUnavailable
+	case errors.Is(err, errGetClientCertificateSecret), errors.Is(err, errClientCertificateKeyMissing):
+		return cfsslissuerapi.IssuerReasonClientCertificateUnavailable
+	case errors.Is(err, signer.ErrInvalidAuthKey):
+		return cfsslissuerapi.IssuerReasonInvalidAuthKey
+	case errors.Is(err, errHealthCheckerBuilder):
+		return cfsslissuerapi.IssuerReasonInvalidConfiguration
+	case errors.Is(err, signer.ErrUnavailable):
+		return cfsslissuerapi.IssuerReasonCFSSLUnavailable
+	case errors.Is(err, signer.ErrLabelRejected):
+		return cfsslissuerapi.IssuerReasonLabelRejected
+	case errors.Is(err, signer.ErrAuthenticationFailed):
+		return cfsslissuerapi.IssuerReasonAuthenticationFailed
+	case errors.Is(err, signer.ErrCAPinMismatch):
+		return cfsslissuerapi.IssuerReasonCAPinMismatch
+	case errors.Is(err, errHealthCheckerCheck):
+		return cfsslissuerapi.IssuerReasonHealthCheckFailed
+	}
+	return cfsslissuerapi.IssuerReasonError
+}
+
+// healthCheckSettings returns the interval and the failure and success
+// thresholds of the health checks of an issuer.
+func healthCheckSettings(issuerSpec *cfsslissuerapi.IssuerSpec) (interval time.Duration, failureThreshold, successThreshold int32) {
+	interval, failureThreshold, successThreshold = defaultHealthCheckInterval, 1, 1
+	if hc := issuerSpec.HealthCheck; hc != nil {
+		if hc.Interval != nil && hc.Interval.Duration > 0 {
+			interval = hc.Interval.Duration
+		}
+		if hc.FailureThreshold > 0 {
+			failureThreshold = hc.FailureThreshold
+		}
+		if hc.SuccessThreshold > 0 {
+			successThreshold = hc.SuccessThreshold
+		}
+	}
+	return interval, failureThreshold, successThreshold
+}
+
+// recordHealthCheck updates the time and streak of the health checks in the
+// status with the outcome of the current one.
+func (r *IssuerReconciler) recordHealthCheck(issuerStatus *cfsslissuerapi.IssuerStatus, success bool) *cfsslissuerapi.HealthCheckStatus {
+	if issuerStatus.HealthCheck == nil {
+		issuerStatus.HealthCheck = &cfsslissuerapi.HealthCheckStatus{}
+	}
+	healthCheck := issuerStatus.HealthCheck
+	healthCheck.LastCheckTime = metav1.NewTime(r.Clock.Now())
+	if success {
+		healthCheck.ConsecutiveSuccesses++
+		healthCheck.ConsecutiveFailures = 0
+	} else {
+		healthCheck.ConsecutiveFailures++
+		healthCheck.ConsecutiveSuccesses = 0
+	}
+	return healthCheck
+}
+
+// handleCARotation records a change of the signer certificate compared to the
+// one in the status, and marks the Certificates referencing the issuer for
+// re-issuance if configured to.
+func (r *IssuerReconciler) handleCARotation(ctx context.Context, issuer client.Object, issuerSpec *cfsslissuerapi.IssuerSpec, issuerStatus *cfsslissuerapi.IssuerStatus, ca *cfsslissuerapi.CAStatus) {
+	previous := issuerStatus.CA
+	if previous == nil || previous.SHA256Fingerprint == ca.SHA256Fingerprint {
+		return
+	}
+
+	message := fmt.Sprintf("Signer certificate changed from %s to %s (%s)", previous.SHA256Fingerprint, ca.SHA256Fingerprint, ca.Subject)
+	if issuerSpec.ReissueOnCARotation {
+		message += ", re-issuing the Certificates referencing the issuer"
+		issuerutil.SetCondition(issuerStatus, issuer.GetGeneration(), cfsslissuerapi.IssuerConditionCertificatesReissued, metav1.ConditionFalse,
+			cfsslissuerapi.IssuerReasonReissuePending, fmt.Sprintf("Re-issuing the Certificates for signer certificate %s", ca.SHA256Fingerprint))
+	}
+	ctrl.LoggerFrom(ctx).Info(message)
+	r.recorder.Event(issuer, corev1.EventTypeNormal, cfsslissuerapi.EventReasonCARotated, message)
+	issuerStatus.LastCARotation = &cfsslissuerapi.CARotation{
+		Time:                      metav1.NewTime(r.Clock.Now()),
+		PreviousSHA256Fingerprint: previous.SHA256Fingerprint,
+		SHA256Fingerprint:         ca.SHA256Fingerprint,
+	}
+}
+
+// reissuePendingCertificates re-issues the next batch of Certificates after a
+// CA rotation, as long as the CertificatesReissued condition is False, and
+// updates the condition. Failures are reported with a Warning Event and
+// retried with the next batch. It returns whether Certificates are left.
+func (r *IssuerReconciler) reissuePendingCertificates(ctx context.Context, issuer client.Object, issuerSpec *cfsslissuerapi.IssuerSpec, issuerStatus *cfsslissuerapi.IssuerStatus) bool {
+	if !issuerSpec.ReissueOnCARotation {
+		issuerutil.RemoveCondition(issuerStatus, cfsslissuerapi.IssuerConditionCertificatesReissued)
+		return false
+	}
+	condition := issuerutil.GetCondition(issuerStatus, cfsslissuerapi.IssuerConditionCertificatesReissued)
+	if condition == nil || condition.Status == metav1.ConditionTrue || issuerStatus.LastCARotation == nil {
+		return false
+	}
+
+	log := ctrl.LoggerFrom(ctx)
+	fingerprint := issuerStatus.LastCARotation.SHA256Fingerprint
+	reissued, pending, err := reissueCertificates(ctx, r.Client, issuer, r.Kind, fingerprint, reissueBatchSize)
+	switch {
+	case err != nil:
+		err = fmt.Errorf("%w: %w", errReissueCertificates, err)
+		message := fmt.Sprintf("Re-issued %d Certificates, %d left: %v", reissued, pending, err)
+		log.Error(err, "Retrying")
+		r.recorder.Event(issuer, corev1.EventTypeWarning, string(cfsslissuerapi.IssuerReasonReissueFailed), message)
+		issuerutil.SetCondition(issuerStatus, issuer.GetGeneration(), cfsslissuerapi.IssuerConditionCertificatesReissued, metav1.ConditionFalse,
+			cfsslissuerapi.IssuerReasonReissueFailed, message)
+		return true
+	case pending > 0:
+		issuerutil.SetCondition(issuerStatus, issuer.GetGeneration(), cfsslissuerapi.IssuerConditionCertificatesReissued, metav1.ConditionFalse,
+			cfsslissuerapi.IssuerReasonReissuePending, fmt.Sprintf("Re-issued %d Certificates for signer certificate %s, %d left", reissued, fingerprint, pending))
+		return true
+	}
+	message := fmt.Sprintf("All Certificates re-issued for signer certificate %s", fingerprint)
+	log.Info(message)
+	r.recorder.Event(issuer, corev1.EventTypeNormal, string(cfsslissuerapi.IssuerReasonReissued), message)
+	issuerutil.SetCondition(issuerStatus, issuer.GetGeneration(), cfsslissuerapi.IssuerConditionCertificatesReissued, metav1.ConditionTrue,
+		cfsslissuerapi.IssuerReasonReissued, message)
+	return false
+}
+
+// setDegradedCondition sets the Degraded condition according to the health of
+// the endpoints and labels in the status. If the health check failed, it is
+// unknown whether the issuer is degraded or down.
+func (r *IssuerReconciler) setDegradedCondition(generation int64, issuerStatus *cfsslissuerapi.IssuerStatus, checkErr error) {
+	if checkErr != nil {
+		issuerutil.SetCondition(issuerStatus, generation, cfsslissuerapi.IssuerConditionDegraded, metav1.ConditionUnknown,
+			issuerReason(checkErr), fmt.Sprintf("Health check failed: %v", checkErr))
+		return
+	}
+	var unhealthyEndpoints, unhealthyLabels []string
+	for _, endpoint := range issuerStatus.Endpoints {
+		if !endpoint.Healthy {
+			unhealthyEndpoints = append(unhealthyEndpoints, endpoint.URL)
+		}
+	}
+	for _, label := range issuerStatus.Labels {
+		if !label.Healthy {
+			unhealthyLabels = append(unhealthyLabels, label.Label)
+		}
+	}
+	switch {
+	case len(unhealthyEndpoints) > 0:
+		issuerutil.SetCondition(issuerStatus, generation, cfsslissuerapi.IssuerConditionDegraded, metav1.ConditionTrue,
+			cfsslissuerapi.IssuerReasonEndpointsUnhealthy, fmt.Sprintf("Unhealthy endpoints: %s", strings.Join(unhealthyEndpoints, ", ")))
+	case len(unhealthyLabels) > 0:
+		issuerutil.SetCondition(issuerStatus, generation, cfsslissuerapi.IssuerConditionDegraded, metav1.ConditionTrue,
+			cfsslissuerapi.IssuerReasonLabelsUnhealthy, fmt.Sprintf("Unhealthy labels: %s", strings.Join(unhealthyLabels, ", ")))
+	default:
+		issuerutil.SetCondition(issuerStatus, generation, cfsslissuerapi.IssuerConditionDegraded, metav1.ConditionFalse,
+			cfsslissuerapi.IssuerReasonHealthy, "All endpoints and labels are healthy")
+	}
+}
+
+// setAuthVerifiedCondition sets the AuthVerified condition according to the
+// outcome of the authenticated health check. It is left unchanged if the check
+// failed before the key could be verified.
+func (r *IssuerReconciler) setAuthVerifiedCondition(generation int64, issuerSpec *cfsslissuerapi.IssuerSpec, issuerStatus *cfsslissuerapi.IssuerStatus, checkResult *signer.HealthCheckResult, checkErr error) {
+	if issuerSpec.HealthCheck == nil || !issuerSpec.HealthCheck.Authenticated {
+		issuerutil.RemoveCondition(issuerStatus, cfsslissuerapi.IssuerConditionAuthVerified)
+		issuerStatus.LastAuthVerifiedTime = nil
+		return
+	}
+	switch {
+	case checkResult != nil && checkResult.AuthVerified:
+		now := metav1.NewTime(r.Clock.Now())
+		issuerStatus.LastAuthVerifiedTime = &now
+		issuerutil.SetCondition(issuerStatus, generation, cfsslissuerapi.IssuerConditionAuthVerified, metav1.ConditionTrue,
+			cfsslissuerapi.IssuerReasonKeyAccepted, fmt.Sprintf("Key in field %q accepted by the CFSSL API", checkResult.AcceptedKey))
+	case errors.Is(checkErr, signer.ErrAuthenticationFailed):
+		issuerutil.SetCondition(issuerStatus, generation, cfsslissuerapi.IssuerConditionAuthVerified, metav1.ConditionFalse,
+			cfsslissuerapi.IssuerReasonAuthenticationFailed, checkErr.Error())
+	}
+}
+
+// setCAExpiringSoonCondition sets the CAExpiringSoon condition according to
+// the expiry of the signer certificate in the status.
+func (r *IssuerReconciler) setCAExpiringSoonCondition(generation int64, issuerSpec *cfsslissuerapi.IssuerSpec, issuerStatus *cfsslissuerapi.IssuerStatus) {
+	window := defaultCAExpiryWarning
+	if issuerSpec.HealthCheck != nil && issuerSpec.HealthCheck.CAExpiryWarning != nil {
+		window = issuerSpec.HealthCheck.CAExpiryWarning.Duration
+	}
+	notAfter := issuerStatus.CA.NotAfter.UTC().Format(time.RFC3339)
+	if r.Clock.Now().Add(window).Before(issuerStatus.CA.NotAfter.Time) {
+		issuerutil.SetCondition(issuerStatus, generation, cfsslissuerapi.IssuerConditionCAExpiringSoon, metav1.ConditionFalse,
+			cfsslissuerapi.IssuerReasonCAValid, fmt.Sprintf("Signer certificate expires at %s", notAfter))
+		return
+	}
+	issuerutil.SetCondition(issuerStatus, generation, cfsslissuerapi.IssuerConditionCAExpiringSoon, metav1.ConditionTrue,
+		cfsslissuerapi.IssuerReasonCAExpiringSoon, fmt.Sprintf("Signer certificate expires at %s, within %s", notAfter, window))
+}
+
+// authSecretName is the IndexerFunc of authSecretNameField.
+func authSecretName(obj client.Object) []string {
+	issuerSpec, _, err := issuerutil.GetSpecAndStatus(obj)
+	if err != nil || issuerSpec.AuthSecretName == "" {
+		return nil
+	}
+	return []string{issuerSpec.AuthSecretName}
+}
+
+// issuersForSecret maps a Secret to the requests of the issuers of the
+// reconciled kind using it as auth Secret.
+func (r *IssuerReconciler) issuersForSecret(ctx context.Context, secret client.Object) []reconcile.Request {
+	issuers, err := r.Scheme.New(cfsslissuerapi.GroupVersion.WithKind(r.Kind + "List"))
+	if err != nil {
+		return nil
+	}
+	opts := []client.ListOption{client.MatchingFields{authSecretNameField: secret.GetName()}}
+	switch r.Kind {
+	case "Issuer":
+		opts = append(opts, client.InNamespace(secret.GetNamespace()))
+	case "ClusterIssuer":
+		if secret.GetNamespace() != r.ClusterResourceNamespace {
+			return nil
+		}
+	}
+	if err := r.List(ctx, issuers.(client.ObjectList), opts...); err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "Failed to list issuers using Secret", "secret", client.ObjectKeyFromObject(secret))
+		return nil
+	}
+	var requests []reconcile.Request
+	_ = meta.EachListItem(issuers, func(obj runtime.Object) error {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(obj.(client.Object))})
+		return nil
+	})
+	return requests
+}
+
+// issuerChanged passes events of an Issuer or ClusterIssuer unless only its
+// metadata or status changed. Every health check updates the status, so it
+// would otherwise run again right away instead of after the interval.
+var issuerChanged = predicate.GenerationChangedPredicate{}
+
 func (r *IssuerReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	issuerType, err := r.newIssuer()
 	if err != nil {
 		return err
 	}
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), issuerType, authSecretNameField, authSecretName); err != nil {
+		return err
+	}
 	r.recorder = mgr.GetEventRecorderFor(cfsslissuerapi.EventSource)
 	return ctrl.NewControllerManagedBy(mgr).
-		For(issuerType).
+		For(issuerType, builder.WithPredicates(issuerChanged)).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.issuersForSecret)).
 		Complete(r)
 }
@@ -2,10 +2,20 @@ package controllers
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
+	cmutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	logrtesting "github.com/go-logr/logr/testing"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -17,6 +27,8 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	cfsslissuerapi "gerrit.wikimedia.org/r/operations/software/cfssl-issuer/api/v1alpha1"
@@ -29,11 +41,40 @@ const (
 )
 
 type fakeHealthChecker struct {
-	errCheck error
+	// Number of times Check was called
+	checks       int
+	errCheck     error
+	endpoints    []cfsslissuerapi.EndpointStatus
+	labels       []cfsslissuerapi.LabelStatus
+	ca           *cfsslissuerapi.CAStatus
+	acceptedKey  string
+	authVerified bool
 }
 
-func (o *fakeHealthChecker) Check() error {
-	return o.errCheck
+func (o *fakeHealthChecker) Check(context.Context) (*signer.HealthCheckResult, error) {
+	o.checks++
+	return &signer.HealthCheckResult{Endpoints: o.endpoints, Labels: o.labels, CA: o.ca, AcceptedKey: o.acceptedKey, AuthVerified: o.authVerified}, o.errCheck
+}
+
+// caStatusWithFingerprint returns a CAStatus of a signer certificate with the
+// given fingerprint, which does not expire soon.
+func caStatusWithFingerprint(fingerprint string) *cfsslissuerapi.CAStatus {
+	ca := caStatusExpiringIn(365 * 24 * time.Hour)
+	ca.SHA256Fingerprint = fingerprint
+	return ca
+}
+
+// caStatusExpiringIn returns a CAStatus of a signer certificate expiring d
+// after fixedClockStart. Times are in local time, like they are read back from
+// the fake client.
+func caStatusExpiringIn(d time.Duration) *cfsslissuerapi.CAStatus {
+	return &cfsslissuerapi.CAStatus{
+		Subject:           "CN=Test CA",
+		SerialNumber:      "1",
+		SHA256Fingerprint: "ba46b17980557d56e916365f29c7e6fc04f84c8f1d6ea77faa183316a63d9393",
+		NotBefore:         metav1.NewTime(fixedClockStart.Add(-365 * 24 * time.Hour).Local()),
+		NotAfter:          metav1.NewTime(fixedClockStart.Add(d).Local()),
+	}
 }
 
 func TestIssuerReconcile(t *testing.T) {
@@ -46,12 +87,1276 @@ func TestIssuerReconcile(t *testing.T) {
 		clusterResourceNamespace     string
 		expectedResult               ctrl.Result
 		expectedError                error
-		expectedReadyConditionStatus cfsslissuerapi.ConditionStatus
+		expectedReadyConditionStatus metav1.ConditionStatus
+		expectedReadyConditionReason cfsslissuerapi.IssuerConditionReason
+		expectedEndpoints            []cfsslissuerapi.EndpointStatus
+		expectedCA                   *cfsslissuerapi.CAStatus
+		expectedAcceptedKey          string
+		expectedHealthCheck          *cfsslissuerapi.HealthCheckStatus
+		// Status and reason of the Degraded and AuthVerified conditions, not
+		// checked if empty
+		expectedDegradedConditionStatus     metav1.ConditionStatus
+		expectedDegradedConditionReason     cfsslissuerapi.IssuerConditionReason
+		expectedAuthVerifiedConditionStatus metav1.ConditionStatus
+		expectedLastAuthVerifiedTime        *metav1.Time
+		// Status of the CAExpiringSoon condition, empty for none
+		expectedCAExpiringSoonConditionStatus metav1.ConditionStatus
+		expectedLastCARotation                *cfsslissuerapi.CARotation
+		certificateObjects                    []client.Object
+		// Names of the Certificates expected to be re-issued
+		expectedReissuedCertificates []string
+		// Status and reason of the CertificatesReissued condition, empty for
+		// none
+		expectedCertificatesReissuedConditionStatus metav1.ConditionStatus
+		expectedCertificatesReissuedConditionReason cfsslissuerapi.IssuerConditionReason
+		interceptorFuncs                            interceptor.Funcs
+		// Events expected before the one of the Ready condition
+		expectedEvents []string
+	}
+
+	// More Certificates than are re-issued at once
+	var manyCertificates []client.Object
+	var firstBatch []string
+	for i := 0; i < reissueBatchSize+2; i++ {
+		name := fmt.Sprintf("crt%02d", i)
+		manyCertificates = append(manyCertificates, testCertificate("ns1", name, "Issuer", "issuer1", ""))
+		if i < reissueBatchSize {
+			firstBatch = append(firstBatch, name)
+		}
 	}
 
 	tests := map[string]testCase{
 		"success-issuer": {
 			kind: "Issuer",
+			name: types.NamespacedName{Namespace: "ns1", Name: "issuer1"},
+			issuerObjects: []client.Object{
+				&cfsslissuerapi.Issuer{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       "issuer1",
+						Namespace:  "ns1",
+						Generation: 2,
+					},
+					Spec: cfsslissuerapi.IssuerSpec{
+						AuthSecretName: "issuer1-credentials",
+						Label:          "issuer1-label",
+						Profile:        "issuer1-profile",
+					},
+					Status: cfsslissuerapi.IssuerStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:   cfsslissuerapi.IssuerConditionReady,
+								Status: metav1.ConditionUnknown,
+							},
+						},
+					},
+				},
+			},
+			secretObjects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "issuer1-credentials",
+						Namespace: "ns1",
+					},
+					Data: map[string][]byte{"key": []byte(validSecretKey)},
+				},
+			},
+			healthCheckerBuilder: func(*cfsslissuerapi.IssuerSpec, *signer.IssuerData) (signer.HealthChecker, error) {
+				return &fakeHealthChecker{}, nil
+			},
+			expectedReadyConditionStatus:    metav1.ConditionTrue,
+			expectedDegradedConditionStatus: metav1.ConditionFalse,
+			expectedDegradedConditionReason: cfsslissuerapi.IssuerReasonHealthy,
+			expectedResult:                  ctrl.Result{RequeueAfter: defaultHealthCheckInterval},
+		},
+		"success-issuer-degraded": {
+			kind: "Issuer",
+			name: types.NamespacedName{Namespace: "ns1", Name: "issuer1"},
+			issuerObjects: []client.Object{
+				&cfsslissuerapi.Issuer{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       "issuer1",
+						Namespace:  "ns1",
+						Generation: 2,
+					},
+					Spec: cfsslissuerapi.IssuerSpec{
+						AuthSecretName: "issuer1-credentials",
+						Label:          "issuer1-label",
+						Profile:        "issuer1-profile",
+					},
+					Status: cfsslissuerapi.IssuerStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:   cfsslissuerapi.IssuerConditionReady,
+								Status: metav1.ConditionUnknown,
+							},
+						},
+					},
+				},
+			},
+			secretObjects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "issuer1-credentials",
+						Namespace: "ns1",
+					},
+					Data: map[string][]byte{"key": []byte(validSecretKey)},
+				},
+			},
+			healthCheckerBuilder: func(*cfsslissuerapi.IssuerSpec, *signer.IssuerData) (signer.HealthChecker, error) {
+				return &fakeHealthChecker{
+					endpoints: []cfsslissuerapi.EndpointStatus{
+						{URL: "https://signer1.example.com", LastError: "simulated health check error", ConsecutiveFailures: 1},
+						{URL: "https://signer2.example.com", Healthy: true},
+					},
+				}, nil
+			},
+			expectedReadyConditionStatus:    metav1.ConditionTrue,
+			expectedDegradedConditionStatus: metav1.ConditionTrue,
+			expectedDegradedConditionReason: cfsslissuerapi.IssuerReasonEndpointsUnhealthy,
+			expectedEndpoints: []cfsslissuerapi.EndpointStatus{
+				{URL: "https://signer1.example.com", LastError: "simulated health check error", ConsecutiveFailures: 1},
+				{URL: "https://signer2.example.com", Healthy: true},
+			},
+			expectedResult: ctrl.Result{RequeueAfter: defaultHealthCheckInterval},
+		},
+		"success-issuer-auth-verified": {
+			kind: "Issuer",
+			name: types.NamespacedName{Namespace: "ns1", Name: "issuer1"},
+			issuerObjects: []client.Object{
+				&cfsslissuerapi.Issuer{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       "issuer1",
+						Namespace:  "ns1",
+						Generation: 2,
+					},
+					Spec: cfsslissuerapi.IssuerSpec{
+						AuthSecretName: "issuer1-credentials",
+						Label:          "issuer1-label",
+						Profile:        "issuer1-profile",
+						HealthCheck: &cfsslissuerapi.HealthCheckConfig{
+							Authenticated: true,
+						},
+					},
+					Status: cfsslissuerapi.IssuerStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:   cfsslissuerapi.IssuerConditionReady,
+								Status: metav1.ConditionUnknown,
+							},
+						},
+					},
+				},
+			},
+			secretObjects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "issuer1-credentials",
+						Namespace: "ns1",
+					},
+					Data: map[string][]byte{"key": []byte(validSecretKey)},
+				},
+			},
+			healthCheckerBuilder: func(*cfsslissuerapi.IssuerSpec, *signer.IssuerData) (signer.HealthChecker, error) {
+				return &fakeHealthChecker{acceptedKey: "key", authVerified: true}, nil
+			},
+			expectedReadyConditionStatus:        metav1.ConditionTrue,
+			expectedAcceptedKey:                 "key",
+			expectedAuthVerifiedConditionStatus: metav1.ConditionTrue,
+			expectedLastAuthVerifiedTime:        &metav1.Time{Time: fixedClockStart.Local()},
+			expectedResult:                      ctrl.Result{RequeueAfter: defaultHealthCheckInterval},
+		},
+		"success-issuer-auth-still-verified": {
+			kind: "Issuer",
+			name: types.NamespacedName{Namespace: "ns1", Name: "issuer1"},
+			issuerObjects: []client.Object{
+				&cfsslissuerapi.Issuer{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       "issuer1",
+						Namespace:  "ns1",
+						Generation: 2,
+					},
+					Spec: cfsslissuerapi.IssuerSpec{
+						AuthSecretName: "issuer1-credentials",
+						Label:          "issuer1-label",
+						Profile:        "issuer1-profile",
+						HealthCheck: &cfsslissuerapi.HealthCheckConfig{
+							Authenticated: true,
+						},
+					},
+					Status: cfsslissuerapi.IssuerStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:   cfsslissuerapi.IssuerConditionReady,
+								Status: metav1.ConditionTrue,
+							},
+							{
+								Type:   cfsslissuerapi.IssuerConditionAuthVerified,
+								Status: metav1.ConditionTrue,
+							},
+						},
+						LastAuthVerifiedTime: &metav1.Time{Time: fixedClockStart.Add(-time.Hour).Local()},
+					},
+				},
+			},
+			secretObjects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "issuer1-credentials",
+						Namespace: "ns1",
+					},
+					Data: map[string][]byte{"key": []byte(validSecretKey)},
+				},
+			},
+			healthCheckerBuilder: func(*cfsslissuerapi.IssuerSpec, *signer.IssuerData) (signer.HealthChecker, error) {
+				return &fakeHealthChecker{acceptedKey: "key", authVerified: true}, nil
+			},
+			expectedReadyConditionStatus:        metav1.ConditionTrue,
+			expectedAcceptedKey:                 "key",
+			expectedAuthVerifiedConditionStatus: metav1.ConditionTrue,
+			// Updated by every check the key passes
+			expectedLastAuthVerifiedTime: &metav1.Time{Time: fixedClockStart.Local()},
+			expectedResult:               ctrl.Result{RequeueAfter: defaultHealthCheckInterval},
+		},
+		"success-issuer-accepted-next-key": {
+			kind: "Issuer",
+			name: types.NamespacedName{Namespace: "ns1", Name: "issuer1"},
+			issuerObjects: []client.Object{
+				&cfsslissuerapi.Issuer{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "issuer1",
+						Namespace: "ns1",
+					},
+					Spec: cfsslissuerapi.IssuerSpec{
+						AuthSecretName: "issuer1-credentials",
+						Label:          "issuer1-label",
+						Profile:        "issuer1-profile",
+					},
+					Status: cfsslissuerapi.IssuerStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:   cfsslissuerapi.IssuerConditionReady,
+								Status: metav1.ConditionUnknown,
+							},
+						},
+					},
+				},
+			},
+			secretObjects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "issuer1-credentials",
+						Namespace: "ns1",
+					},
+					Data: map[string][]byte{
+						"key":      []byte(validSecretKey),
+						"key.next": []byte(validSecretKey),
+					},
+				},
+			},
+			healthCheckerBuilder: func(*cfsslissuerapi.IssuerSpec, *signer.IssuerData) (signer.HealthChecker, error) {
+				return &fakeHealthChecker{acceptedKey: "key.next"}, nil
+			},
+			expectedReadyConditionStatus: metav1.ConditionTrue,
+			expectedResult:               ctrl.Result{RequeueAfter: defaultHealthCheckInterval},
+			expectedAcceptedKey:          "key.next",
+		},
+		"success-issuer-accepted-key-unknown": {
+			kind: "Issuer",
+			name: types.NamespacedName{Namespace: "ns1", Name: "issuer1"},
+			issuerObjects: []client.Object{
+				&cfsslissuerapi.Issuer{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "issuer1",
+						Namespace: "ns1",
+					},
+					Spec: cfsslissuerapi.IssuerSpec{
+						AuthSecretName: "issuer1-credentials",
+						Label:          "issuer1-label",
+						Profile:        "issuer1-profile",
+					},
+					Status: cfsslissuerapi.IssuerStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:   cfsslissuerapi.IssuerConditionReady,
+								Status: metav1.ConditionUnknown,
+							},
+						},
+						AcceptedKey: "key.next",
+					},
+				},
+			},
+			secretObjects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "issuer1-credentials",
+						Namespace: "ns1",
+					},
+					Data: map[string][]byte{
+						"key":      []byte(validSecretKey),
+						"key.next": []byte(validSecretKey),
+					},
+				},
+			},
+			healthCheckerBuilder: func(*cfsslissuerapi.IssuerSpec, *signer.IssuerData) (signer.HealthChecker, error) {
+				// No request was authenticated since the controller started
+				return &fakeHealthChecker{}, nil
+			},
+			expectedReadyConditionStatus: metav1.ConditionTrue,
+			expectedResult:               ctrl.Result{RequeueAfter: defaultHealthCheckInterval},
+			expectedAcceptedKey:          "key.next",
+		},
+		"success-issuer-ca-rotated": {
+			kind: "Issuer",
+			name: types.NamespacedName{Namespace: "ns1", Name: "issuer1"},
+			issuerObjects: []client.Object{
+				&cfsslissuerapi.Issuer{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "issuer1",
+						Namespace: "ns1",
+					},
+					Spec: cfsslissuerapi.IssuerSpec{
+						AuthSecretName: "issuer1-credentials",
+						Label:          "issuer1-label",
+						Profile:        "issuer1-profile",
+					},
+					Status: cfsslissuerapi.IssuerStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:   cfsslissuerapi.IssuerConditionReady,
+								Status: metav1.ConditionUnknown,
+							},
+						},
+						CA: caStatusWithFingerprint("0ld"),
+					},
+				},
+			},
+			secretObjects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "issuer1-credentials",
+						Namespace: "ns1",
+					},
+					Data: map[string][]byte{"key": []byte(validSecretKey)},
+				},
+			},
+			healthCheckerBuilder: func(*cfsslissuerapi.IssuerSpec, *signer.IssuerData) (signer.HealthChecker, error) {
+				return &fakeHealthChecker{ca: caStatusWithFingerprint("new")}, nil
+			},
+			expectedReadyConditionStatus:          metav1.ConditionTrue,
+			expectedResult:                        ctrl.Result{RequeueAfter: defaultHealthCheckInterval},
+			expectedCA:                            caStatusWithFingerprint("new"),
+			expectedCAExpiringSoonConditionStatus: metav1.ConditionFalse,
+			expectedLastCARotation: &cfsslissuerapi.CARotation{
+				Time:                      metav1.NewTime(fixedClockStart.Local()),
+				PreviousSHA256Fingerprint: "0ld",
+				SHA256Fingerprint:         "new",
+			},
+			expectedEvents: []string{
+				"Normal CARotated Signer certificate changed from 0ld to new (CN=Test CA)",
+			},
+		},
+		"success-issuer-ca-rotated-reissue": {
+			kind: "Issuer",
+			name: types.NamespacedName{Namespace: "ns1", Name: "issuer1"},
+			issuerObjects: []client.Object{
+				&cfsslissuerapi.Issuer{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "issuer1",
+						Namespace: "ns1",
+					},
+					Spec: cfsslissuerapi.IssuerSpec{
+						AuthSecretName:      "issuer1-credentials",
+						Label:               "issuer1-label",
+						Profile:             "issuer1-profile",
+						ReissueOnCARotation: true,
+					},
+					Status: cfsslissuerapi.IssuerStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:   cfsslissuerapi.IssuerConditionReady,
+								Status: metav1.ConditionUnknown,
+							},
+						},
+						CA: caStatusWithFingerprint("0ld"),
+					},
+				},
+			},
+			secretObjects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "issuer1-credentials",
+						Namespace: "ns1",
+					},
+					Data: map[string][]byte{"key": []byte(validSecretKey)},
+				},
+			},
+			healthCheckerBuilder: func(*cfsslissuerapi.IssuerSpec, *signer.IssuerData) (signer.HealthChecker, error) {
+				return &fakeHealthChecker{ca: caStatusWithFingerprint("new")}, nil
+			},
+			expectedReadyConditionStatus:          metav1.ConditionTrue,
+			expectedResult:                        ctrl.Result{RequeueAfter: defaultHealthCheckInterval},
+			expectedCA:                            caStatusWithFingerprint("new"),
+			expectedCAExpiringSoonConditionStatus: metav1.ConditionFalse,
+			expectedLastCARotation: &cfsslissuerapi.CARotation{
+				Time:                      metav1.NewTime(fixedClockStart.Local()),
+				PreviousSHA256Fingerprint: "0ld",
+				SHA256Fingerprint:         "new",
+			},
+			certificateObjects: []client.Object{
+				testCertificate("ns1", "crt1", "Issuer", "issuer1", ""),
+				testCertificate("ns1", "crt-rotated", "Issuer", "issuer1", "new"),
+				testCertificate("ns1", "crt-other-issuer", "Issuer", "issuer2", ""),
+				testCertificate("ns1", "crt-other-kind", "ClusterIssuer", "issuer1", ""),
+				testCertificate("ns2", "crt-other-namespace", "Issuer", "issuer1", ""),
+			},
+			expectedReissuedCertificates: []string{"crt1"},
+			expectedEvents: []string{
+				"Normal CARotated Signer certificate changed from 0ld to new (CN=Test CA), re-issuing the Certificates referencing the issuer",
+				"Normal Reissued All Certificates re-issued for signer certificate new",
+			},
+			expectedCertificatesReissuedConditionStatus: metav1.ConditionTrue,
+			expectedCertificatesReissuedConditionReason: cfsslissuerapi.IssuerReasonReissued,
+		},
+		"success-issuer-ca-rotated-reissue-batch": {
+			kind: "Issuer",
+			name: types.NamespacedName{Namespace: "ns1", Name: "issuer1"},
+			issuerObjects: []client.Object{
+				&cfsslissuerapi.Issuer{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "issuer1",
+						Namespace: "ns1",
+					},
+					Spec: cfsslissuerapi.IssuerSpec{
+						AuthSecretName:      "issuer1-credentials",
+						Label:               "issuer1-label",
+						Profile:             "issuer1-profile",
+						ReissueOnCARotation: true,
+					},
+					Status: cfsslissuerapi.IssuerStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:   cfsslissuerapi.IssuerConditionReady,
+								Status: metav1.ConditionUnknown,
+							},
+						},
+						CA: caStatusWithFingerprint("0ld"),
+					},
+				},
+			},
+			secretObjects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "issuer1-credentials",
+						Namespace: "ns1",
+					},
+					Data: map[string][]byte{"key": []byte(validSecretKey)},
+				},
+			},
+			healthCheckerBuilder: func(*cfsslissuerapi.IssuerSpec, *signer.IssuerData) (signer.HealthChecker, error) {
+				return &fakeHealthChecker{ca: caStatusWithFingerprint("new")}, nil
+			},
+			expectedReadyConditionStatus:          metav1.ConditionTrue,
+			expectedResult:                        ctrl.Result{RequeueAfter: reissueBatchInterval},
+			expectedCA:                            caStatusWithFingerprint("new"),
+			expectedCAExpiringSoonConditionStatus: metav1.ConditionFalse,
+			expectedLastCARotation: &cfsslissuerapi.CARotation{
+				Time:                      metav1.NewTime(fixedClockStart.Local()),
+				PreviousSHA256Fingerprint: "0ld",
+				SHA256Fingerprint:         "new",
+			},
+			certificateObjects:           manyCertificates,
+			expectedReissuedCertificates: firstBatch,
+			expectedEvents: []string{
+				"Normal CARotated Signer certificate changed from 0ld to new (CN=Test CA), re-issuing the Certificates referencing the issuer",
+			},
+			expectedCertificatesReissuedConditionStatus: metav1.ConditionFalse,
+			expectedCertificatesReissuedConditionReason: cfsslissuerapi.IssuerReasonReissuePending,
+		},
+		"success-issuer-reissue-continued": {
+			kind: "Issuer",
+			name: types.NamespacedName{Namespace: "ns1", Name: "issuer1"},
+			issuerObjects: []client.Object{
+				&cfsslissuerapi.Issuer{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "issuer1",
+						Namespace: "ns1",
+					},
+					Spec: cfsslissuerapi.IssuerSpec{
+						AuthSecretName:      "issuer1-credentials",
+						Label:               "issuer1-label",
+						Profile:             "issuer1-profile",
+						ReissueOnCARotation: true,
+					},
+					Status: cfsslissuerapi.IssuerStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:   cfsslissuerapi.IssuerConditionReady,
+								Status: metav1.ConditionUnknown,
+							},
+							{
+								Type:   cfsslissuerapi.IssuerConditionCertificatesReissued,
+								Status: metav1.ConditionFalse,
+								Reason: string(cfsslissuerapi.IssuerReasonReissuePending),
+							},
+						},
+						CA: caStatusWithFingerprint("new"),
+						LastCARotation: &cfsslissuerapi.CARotation{
+							Time:                      metav1.NewTime(fixedClockStart.Add(-time.Minute)),
+							PreviousSHA256Fingerprint: "0ld",
+							SHA256Fingerprint:         "new",
+						},
+					},
+				},
+			},
+			secretObjects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "issuer1-credentials",
+						Namespace: "ns1",
+					},
+					Data: map[string][]byte{"key": []byte(validSecretKey)},
+				},
+			},
+			healthCheckerBuilder: func(*cfsslissuerapi.IssuerSpec, *signer.IssuerData) (signer.HealthChecker, error) {
+				return &fakeHealthChecker{ca: caStatusWithFingerprint("new")}, nil
+			},
+			expectedReadyConditionStatus:          metav1.ConditionTrue,
+			expectedResult:                        ctrl.Result{RequeueAfter: defaultHealthCheckInterval},
+			expectedCA:                            caStatusWithFingerprint("new"),
+			expectedCAExpiringSoonConditionStatus: metav1.ConditionFalse,
+			expectedLastCARotation: &cfsslissuerapi.CARotation{
+				Time:                      metav1.NewTime(fixedClockStart.Add(-time.Minute).Local()),
+				PreviousSHA256Fingerprint: "0ld",
+				SHA256Fingerprint:         "new",
+			},
+			certificateObjects: []client.Object{
+				testCertificate("ns1", "crt1", "Issuer", "issuer1", ""),
+				testCertificate("ns1", "crt-rotated", "Issuer", "issuer1", "new"),
+				testCertificate("ns1", "crt2", "Issuer", "issuer1", ""),
+				testCertificate("ns1", "crt-other-issuer", "Issuer", "issuer2", ""),
+				testCertificate("ns1", "crt-other-kind", "ClusterIssuer", "issuer1", ""),
+				testCertificate("ns2", "crt-other-namespace", "Issuer", "issuer1", ""),
+			},
+			expectedReissuedCertificates: []string{"crt1", "crt2"},
+			expectedEvents: []string{
+				"Normal Reissued All Certificates re-issued for signer certificate new",
+			},
+			expectedCertificatesReissuedConditionStatus: metav1.ConditionTrue,
+			expectedCertificatesReissuedConditionReason: cfsslissuerapi.IssuerReasonReissued,
+		},
+		"success-issuer-ca-rotated-reissue-failed": {
+			kind: "Issuer",
+			name: types.NamespacedName{Namespace: "ns1", Name: "issuer1"},
+			issuerObjects: []client.Object{
+				&cfsslissuerapi.Issuer{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "issuer1",
+						Namespace: "ns1",
+					},
+					Spec: cfsslissuerapi.IssuerSpec{
+						AuthSecretName:      "issuer1-credentials",
+						Label:               "issuer1-label",
+						Profile:             "issuer1-profile",
+						ReissueOnCARotation: true,
+					},
+					Status: cfsslissuerapi.IssuerStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:   cfsslissuerapi.IssuerConditionReady,
+								Status: metav1.ConditionUnknown,
+							},
+						},
+						CA: caStatusWithFingerprint("0ld"),
+					},
+				},
+			},
+			secretObjects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "issuer1-credentials",
+						Namespace: "ns1",
+					},
+					Data: map[string][]byte{"key": []byte(validSecretKey)},
+				},
+			},
+			healthCheckerBuilder: func(*cfsslissuerapi.IssuerSpec, *signer.IssuerData) (signer.HealthChecker, error) {
+				return &fakeHealthChecker{ca: caStatusWithFingerprint("new")}, nil
+			},
+			expectedReadyConditionStatus:          metav1.ConditionTrue,
+			expectedReadyConditionReason:          cfsslissuerapi.IssuerReasonHealthy,
+			expectedResult:                        ctrl.Result{RequeueAfter: reissueBatchInterval},
+			expectedCA:                            caStatusWithFingerprint("new"),
+			expectedCAExpiringSoonConditionStatus: metav1.ConditionFalse,
+			expectedLastCARotation: &cfsslissuerapi.CARotation{
+				Time:                      metav1.NewTime(fixedClockStart.Local()),
+				PreviousSHA256Fingerprint: "0ld",
+				SHA256Fingerprint:         "new",
+			},
+			certificateObjects: []client.Object{
+				testCertificate("ns1", "crt1", "Issuer", "issuer1", ""),
+				testCertificate("ns1", "crt-rotated", "Issuer", "issuer1", "new"),
+				testCertificate("ns1", "crt-other-issuer", "Issuer", "issuer2", ""),
+				testCertificate("ns1", "crt-other-kind", "ClusterIssuer", "issuer1", ""),
+				testCertificate("ns2", "crt-other-namespace", "Issuer", "issuer1", ""),
+			},
+			interceptorFuncs: interceptor.Funcs{
+				SubResourcePatch: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+					if _, ok := obj.(*cmapi.Certificate); ok {
+						return errors.New("simulated failure")
+					}
+					return c.SubResource(subResourceName).Patch(ctx, obj, patch, opts...)
+				},
+			},
+			expectedEvents: []string{
+				"Normal CARotated Signer certificate changed from 0ld to new (CN=Test CA), re-issuing the Certificates referencing the issuer",
+				"Warning ReissueFailed Re-issued 0 Certificates, 1 left: failed to re-issue Certificates after CA rotation: simulated failure",
+			},
+			expectedCertificatesReissuedConditionStatus: metav1.ConditionFalse,
+			expectedCertificatesReissuedConditionReason: cfsslissuerapi.IssuerReasonReissueFailed,
+		},
+		"issuer-failing-healthchecker-ca-pin-no-reissue": {
+			kind: "Issuer",
+			name: types.NamespacedName{Namespace: "ns1", Name: "issuer1"},
+			issuerObjects: []client.Object{
+				&cfsslissuerapi.Issuer{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "issuer1",
+						Namespace: "ns1",
+					},
+					Spec: cfsslissuerapi.IssuerSpec{
+						AuthSecretName:      "issuer1-credentials",
+						Label:               "issuer1-label",
+						Profile:             "issuer1-profile",
+						ReissueOnCARotation: true,
+					},
+					Status: cfsslissuerapi.IssuerStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:   cfsslissuerapi.IssuerConditionReady,
+								Status: metav1.ConditionUnknown,
+							},
+						},
+						CA: caStatusWithFingerprint("0ld"),
+					},
+				},
+			},
+			secretObjects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "issuer1-credentials",
+						Namespace: "ns1",
+					},
+					Data: map[string][]byte{"key": []byte(validSecretKey)},
+				},
+			},
+			healthCheckerBuilder: func(*cfsslissuerapi.IssuerSpec, *signer.IssuerData) (signer.HealthChecker, error) {
+				// The CA is reported even though it does not match the pins
+				return &fakeHealthChecker{
+					ca:       caStatusWithFingerprint("new"),
+					errCheck: fmt.Errorf("%w: simulated signer certificate", signer.ErrCAPinMismatch),
+				}, nil
+			},
+			expectedError:                errHealthCheckerCheck,
+			expectedReadyConditionStatus: metav1.ConditionFalse,
+			expectedReadyConditionReason: cfsslissuerapi.IssuerReasonCAPinMismatch,
+			// A rejected CA is neither recorded nor re-issued for
+			expectedCA: caStatusWithFingerprint("0ld"),
+			certificateObjects: []client.Object{
+				testCertificate("ns1", "crt1", "Issuer", "issuer1", ""),
+			},
+		},
+		"success-issuer-ca-unchanged": {
+			kind: "Issuer",
+			name: types.NamespacedName{Namespace: "ns1", Name: "issuer1"},
+			issuerObjects: []client.Object{
+				&cfsslissuerapi.Issuer{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "issuer1",
+						Namespace: "ns1",
+					},
+					Spec: cfsslissuerapi.IssuerSpec{
+						AuthSecretName: "issuer1-credentials",
+						Label:          "issuer1-label",
+						Profile:        "issuer1-profile",
+					},
+					Status: cfsslissuerapi.IssuerStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:   cfsslissuerapi.IssuerConditionReady,
+								Status: metav1.ConditionUnknown,
+							},
+						},
+						CA: caStatusWithFingerprint("0ld"),
+					},
+				},
+			},
+			secretObjects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "issuer1-credentials",
+						Namespace: "ns1",
+					},
+					Data: map[string][]byte{"key": []byte(validSecretKey)},
+				},
+			},
+			healthCheckerBuilder: func(*cfsslissuerapi.IssuerSpec, *signer.IssuerData) (signer.HealthChecker, error) {
+				return &fakeHealthChecker{ca: caStatusWithFingerprint("0ld")}, nil
+			},
+			expectedReadyConditionStatus:          metav1.ConditionTrue,
+			expectedResult:                        ctrl.Result{RequeueAfter: defaultHealthCheckInterval},
+			expectedCA:                            caStatusWithFingerprint("0ld"),
+			expectedCAExpiringSoonConditionStatus: metav1.ConditionFalse,
+		},
+		"success-issuer-ca-expiring-soon": {
+			kind: "Issuer",
+			name: types.NamespacedName{Namespace: "ns1", Name: "issuer1"},
+			issuerObjects: []client.Object{
+				&cfsslissuerapi.Issuer{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "issuer1",
+						Namespace: "ns1",
+					},
+					Spec: cfsslissuerapi.IssuerSpec{
+						AuthSecretName: "issuer1-credentials",
+						Label:          "issuer1-label",
+						Profile:        "issuer1-profile",
+					},
+					Status: cfsslissuerapi.IssuerStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:   cfsslissuerapi.IssuerConditionReady,
+								Status: metav1.ConditionUnknown,
+							},
+						},
+					},
+				},
+			},
+			secretObjects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "issuer1-credentials",
+						Namespace: "ns1",
+					},
+					Data: map[string][]byte{"key": []byte(validSecretKey)},
+				},
+			},
+			healthCheckerBuilder: func(*cfsslissuerapi.IssuerSpec, *signer.IssuerData) (signer.HealthChecker, error) {
+				return &fakeHealthChecker{ca: caStatusExpiringIn(7 * 24 * time.Hour)}, nil
+			},
+			expectedReadyConditionStatus:          metav1.ConditionTrue,
+			expectedResult:                        ctrl.Result{RequeueAfter: defaultHealthCheckInterval},
+			expectedCA:                            caStatusExpiringIn(7 * 24 * time.Hour),
+			expectedCAExpiringSoonConditionStatus: metav1.ConditionTrue,
+		},
+		"success-issuer-ca-expiry-warning": {
+			kind: "Issuer",
+			name: types.NamespacedName{Namespace: "ns1", Name: "issuer1"},
+			issuerObjects: []client.Object{
+				&cfsslissuerapi.Issuer{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "issuer1",
+						Namespace: "ns1",
+					},
+					Spec: cfsslissuerapi.IssuerSpec{
+						AuthSecretName: "issuer1-credentials",
+						Label:          "issuer1-label",
+						Profile:        "issuer1-profile",
+						HealthCheck: &cfsslissuerapi.HealthCheckConfig{
+							CAExpiryWarning: &metav1.Duration{Duration: 24 * time.Hour},
+						},
+					},
+					Status: cfsslissuerapi.IssuerStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:   cfsslissuerapi.IssuerConditionReady,
+								Status: metav1.ConditionUnknown,
+							},
+						},
+					},
+				},
+			},
+			secretObjects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "issuer1-credentials",
+						Namespace: "ns1",
+					},
+					Data: map[string][]byte{"key": []byte(validSecretKey)},
+				},
+			},
+			healthCheckerBuilder: func(*cfsslissuerapi.IssuerSpec, *signer.IssuerData) (signer.HealthChecker, error) {
+				return &fakeHealthChecker{ca: caStatusExpiringIn(7 * 24 * time.Hour)}, nil
+			},
+			expectedReadyConditionStatus:          metav1.ConditionTrue,
+			expectedResult:                        ctrl.Result{RequeueAfter: defaultHealthCheckInterval},
+			expectedCA:                            caStatusExpiringIn(7 * 24 * time.Hour),
+			expectedCAExpiringSoonConditionStatus: metav1.ConditionFalse,
+		},
+		"success-clusterissuer": {
+			kind: "ClusterIssuer",
+			name: types.NamespacedName{Name: "clusterissuer1"},
+			issuerObjects: []client.Object{
+				&cfsslissuerapi.ClusterIssuer{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "clusterissuer1",
+					},
+					Spec: cfsslissuerapi.IssuerSpec{
+						AuthSecretName: "clusterissuer1-credentials",
+						Label:          "clusterissuer1-label",
+						Profile:        "clusterissuer1-profile",
+					},
+					Status: cfsslissuerapi.IssuerStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:   cfsslissuerapi.IssuerConditionReady,
+								Status: metav1.ConditionUnknown,
+							},
+						},
+					},
+				},
+			},
+			secretObjects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "clusterissuer1-credentials",
+						Namespace: "kube-system",
+					},
+					Data: map[string][]byte{"key": []byte(validSecretKey)},
+				},
+			},
+			healthCheckerBuilder: func(*cfsslissuerapi.IssuerSpec, *signer.IssuerData) (signer.HealthChecker, error) {
+				return &fakeHealthChecker{}, nil
+			},
+			clusterResourceNamespace:     "kube-system",
+			expectedReadyConditionStatus: metav1.ConditionTrue,
+			expectedResult:               ctrl.Result{RequeueAfter: defaultHealthCheckInterval},
+		},
+		"success-issuer-ca-bundle-ref": {
+			name: types.NamespacedName{Namespace: "ns1", Name: "issuer1"},
+			issuerObjects: []client.Object{
+				&cfsslissuerapi.Issuer{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "issuer1",
+						Namespace: "ns1",
+					},
+					Spec: cfsslissuerapi.IssuerSpec{
+						AuthSecretName: "issuer1-credentials",
+						Label:          "issuer1-label",
+						CABundle:       []byte("inline"),
+						CABundleRef: &cfsslissuerapi.CABundleReference{
+							Kind: cfsslissuerapi.CABundleReferenceKindConfigMap,
+							Name: "issuer1-ca",
+						},
+					},
+					Status: cfsslissuerapi.IssuerStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:   cfsslissuerapi.IssuerConditionReady,
+								Status: metav1.ConditionUnknown,
+							},
+						},
+					},
+				},
+			},
+			secretObjects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "issuer1-credentials",
+						Namespace: "ns1",
+					},
+					Data: map[string][]byte{"key": []byte(validSecretKey)},
+				},
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "issuer1-ca",
+						Namespace: "ns1",
+					},
+					Data: map[string]string{"ca.crt": "referenced"},
+				},
+			},
+			healthCheckerBuilder: func(_ *cfsslissuerapi.IssuerSpec, issuerData *signer.IssuerData) (signer.HealthChecker, error) {
+				if string(issuerData.CABundle) != "inline\nreferenced" {
+					return nil, fmt.Errorf("unexpected CA bundle: %q", issuerData.CABundle)
+				}
+				return &fakeHealthChecker{}, nil
+			},
+			expectedReadyConditionStatus: metav1.ConditionTrue,
+			expectedResult:               ctrl.Result{RequeueAfter: defaultHealthCheckInterval},
+		},
+		"issuer-missing-ca-bundle-ref": {
+			name: types.NamespacedName{Namespace: "ns1", Name: "issuer1"},
+			issuerObjects: []client.Object{
+				&cfsslissuerapi.Issuer{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "issuer1",
+						Namespace: "ns1",
+					},
+					Spec: cfsslissuerapi.IssuerSpec{
+						AuthSecretName: "issuer1-credentials",
+						Label:          "issuer1-label",
+						CABundleRef: &cfsslissuerapi.CABundleReference{
+							Kind: cfsslissuerapi.CABundleReferenceKindSecret,
+							Name: "issuer1-ca",
+						},
+					},
+					Status: cfsslissuerapi.IssuerStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:   cfsslissuerapi.IssuerConditionReady,
+								Status: metav1.ConditionUnknown,
+							},
+						},
+					},
+				},
+			},
+			secretObjects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "issuer1-credentials",
+						Namespace: "ns1",
+					},
+					Data: map[string][]byte{"key": []byte(validSecretKey)},
+				},
+			},
+			expectedError:                errGetCABundle,
+			expectedReadyConditionStatus: metav1.ConditionFalse,
+			expectedReadyConditionReason: cfsslissuerapi.IssuerReasonCABundleUnavailable,
+		},
+		"issuer-ca-bundle-ref-key-missing": {
+			name: types.NamespacedName{Namespace: "ns1", Name: "issuer1"},
+			issuerObjects: []client.Object{
+				&cfsslissuerapi.Issuer{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "issuer1",
+						Namespace: "ns1",
+					},
+					Spec: cfsslissuerapi.IssuerSpec{
+						AuthSecretName: "issuer1-credentials",
+						Label:          "issuer1-label",
+						CABundleRef: &cfsslissuerapi.CABundleReference{
+							Kind: cfsslissuerapi.CABundleReferenceKindSecret,
+							Name: "issuer1-ca",
+							Key:  "bundle.pem",
+						},
+					},
+					Status: cfsslissuerapi.IssuerStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:   cfsslissuerapi.IssuerConditionReady,
+								Status: metav1.ConditionUnknown,
+							},
+						},
+					},
+				},
+			},
+			secretObjects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "issuer1-credentials",
+						Namespace: "ns1",
+					},
+					Data: map[string][]byte{"key": []byte(validSecretKey)},
+				},
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "issuer1-ca",
+						Namespace: "ns1",
+					},
+					Data: map[string][]byte{"ca.crt": []byte("referenced")},
+				},
+			},
+			expectedError:                errCABundleKeyMissing,
+			expectedReadyConditionStatus: metav1.ConditionFalse,
+			expectedReadyConditionReason: cfsslissuerapi.IssuerReasonCABundleUnavailable,
+		},
+		"issuer-client-certificate-secret-incomplete": {
+			name: types.NamespacedName{Namespace: "ns1", Name: "issuer1"},
+			issuerObjects: []client.Object{
+				&cfsslissuerapi.Issuer{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "issuer1",
+						Namespace: "ns1",
+					},
+					Spec: cfsslissuerapi.IssuerSpec{
+						AuthSecretName:              "issuer1-credentials",
+						Label:                       "issuer1-label",
+						ClientCertificateSecretName: "issuer1-client-tls",
+					},
+					Status: cfsslissuerapi.IssuerStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:   cfsslissuerapi.IssuerConditionReady,
+								Status: metav1.ConditionUnknown,
+							},
+						},
+					},
+				},
+			},
+			secretObjects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "issuer1-credentials",
+						Namespace: "ns1",
+					},
+					Data: map[string][]byte{"key": []byte(validSecretKey)},
+				},
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "issuer1-client-tls",
+						Namespace: "ns1",
+					},
+					Type: corev1.SecretTypeTLS,
+					Data: map[string][]byte{"tls.crt": []byte("cert")},
+				},
+			},
+			expectedError:                errClientCertificateKeyMissing,
+			expectedReadyConditionStatus: metav1.ConditionFalse,
+			expectedReadyConditionReason: cfsslissuerapi.IssuerReasonClientCertificateUnavailable,
+		},
+		"issuer-kind-unrecognised": {
+			kind: "UnrecognizedType",
+			name: types.NamespacedName{Namespace: "ns1", Name: "issuer1"},
+		},
+		"issuer-not-found": {
+			name: types.NamespacedName{Namespace: "ns1", Name: "issuer1"},
+		},
+		"issuer-missing-ready-condition": {
+			name: types.NamespacedName{Namespace: "ns1", Name: "issuer1"},
+			issuerObjects: []client.Object{
+				&cfsslissuerapi.Issuer{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "issuer1",
+						Namespace: "ns1",
+					},
+				},
+			},
+			expectedReadyConditionStatus: metav1.ConditionUnknown,
+			expectedResult:               ctrl.Result{Requeue: true},
+		},
+		"issuer-missing-secret": {
+			name: types.NamespacedName{Namespace: "ns1", Name: "issuer1"},
+			issuerObjects: []client.Object{
+				&cfsslissuerapi.Issuer{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "issuer1",
+						Namespace: "ns1",
+					},
+					Spec: cfsslissuerapi.IssuerSpec{
+						AuthSecretName: "issuer1-credentials",
+						Label:          "issuer1-label",
+						Profile:        "issuer1-profile",
+					},
+					Status: cfsslissuerapi.IssuerStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:   cfsslissuerapi.IssuerConditionReady,
+								Status: metav1.ConditionUnknown,
+							},
+						},
+					},
+				},
+			},
+			expectedError:                errGetAuthSecret,
+			expectedReadyConditionStatus: metav1.ConditionFalse,
+			expectedReadyConditionReason: cfsslissuerapi.IssuerReasonAuthSecretUnavailable,
+		},
+		"issuer-missing-secret-key": {
+			name: types.NamespacedName{Namespace: "ns1", Name: "issuer1"},
+			issuerObjects: []client.Object{
+				&cfsslissuerapi.Issuer{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "issuer1",
+						Namespace: "ns1",
+					},
+					Spec: cfsslissuerapi.IssuerSpec{
+						AuthSecretName: "issuer1-credentials",
+						Label:          "issuer1-label",
+						Profile:        "issuer1-profile",
+					},
+					Status: cfsslissuerapi.IssuerStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:   cfsslissuerapi.IssuerConditionReady,
+								Status: metav1.ConditionUnknown,
+							},
+						},
+					},
+				},
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "issuer1-credentials",
+						Namespace: "ns1",
+					},
+				},
+			},
+			expectedError:                errAuthSecretKeyMissing,
+			expectedReadyConditionStatus: metav1.ConditionFalse,
+			expectedReadyConditionReason: cfsslissuerapi.IssuerReasonAuthSecretKeyMissing,
+		},
+		"issuer-failing-healthchecker-builder": {
+			name: types.NamespacedName{Namespace: "ns1", Name: "issuer1"},
+			issuerObjects: []client.Object{
+				&cfsslissuerapi.Issuer{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "issuer1",
+						Namespace: "ns1",
+					},
+					Spec: cfsslissuerapi.IssuerSpec{
+						AuthSecretName: "issuer1-credentials",
+						Label:          "issuer1-label",
+						Profile:        "issuer1-profile",
+					},
+					Status: cfsslissuerapi.IssuerStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:   cfsslissuerapi.IssuerConditionReady,
+								Status: metav1.ConditionUnknown,
+							},
+						},
+					},
+				},
+			},
+			secretObjects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "issuer1-credentials",
+						Namespace: "ns1",
+					},
+					Data: map[string][]byte{"key": []byte(validSecretKey)},
+				},
+			},
+			healthCheckerBuilder: func(*cfsslissuerapi.IssuerSpec, *signer.IssuerData) (signer.HealthChecker, error) {
+				return nil, errors.New("simulated health checker builder error")
+			},
+			expectedError:                errHealthCheckerBuilder,
+			expectedReadyConditionStatus: metav1.ConditionFalse,
+			expectedReadyConditionReason: cfsslissuerapi.IssuerReasonInvalidConfiguration,
+		},
+		"issuer-failing-healthchecker-builder-auth-key": {
+			name: types.NamespacedName{Namespace: "ns1", Name: "issuer1"},
+			issuerObjects: []client.Object{
+				&cfsslissuerapi.Issuer{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "issuer1",
+						Namespace: "ns1",
+					},
+					Spec: cfsslissuerapi.IssuerSpec{
+						AuthSecretName: "issuer1-credentials",
+						URL:            "https://cfssl.example.com",
+						Label:          "issuer1-label",
+						Profile:        "issuer1-profile",
+					},
+					Status: cfsslissuerapi.IssuerStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:   cfsslissuerapi.IssuerConditionReady,
+								Status: metav1.ConditionUnknown,
+							},
+						},
+					},
+				},
+			},
+			secretObjects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "issuer1-credentials",
+						Namespace: "ns1",
+					},
+					Data: map[string][]byte{"key": []byte("not hex")},
+				},
+			},
+			healthCheckerBuilder:         signer.NewCfsslHealthChecker,
+			expectedError:                errHealthCheckerBuilder,
+			expectedReadyConditionStatus: metav1.ConditionFalse,
+			expectedReadyConditionReason: cfsslissuerapi.IssuerReasonInvalidAuthKey,
+		},
+		"issuer-failing-healthchecker-check": {
+			name: types.NamespacedName{Namespace: "ns1", Name: "issuer1"},
+			issuerObjects: []client.Object{
+				&cfsslissuerapi.Issuer{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "issuer1",
+						Namespace: "ns1",
+					},
+					Spec: cfsslissuerapi.IssuerSpec{
+						AuthSecretName: "issuer1-credentials",
+						Label:          "issuer1-label",
+						Profile:        "issuer1-profile",
+					},
+					Status: cfsslissuerapi.IssuerStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:   cfsslissuerapi.IssuerConditionReady,
+								Status: metav1.ConditionUnknown,
+							},
+						},
+					},
+				},
+			},
+			secretObjects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "issuer1-credentials",
+						Namespace: "ns1",
+					},
+					Data: map[string][]byte{"key": []byte(validSecretKey)},
+				},
+			},
+			healthCheckerBuilder: func(*cfsslissuerapi.IssuerSpec, *signer.IssuerData) (signer.HealthChecker, error) {
+				return &fakeHealthChecker{errCheck: errors.New("simulated health check error")}, nil
+			},
+			expectedError:                errHealthCheckerCheck,
+			expectedReadyConditionStatus: metav1.ConditionFalse,
+			expectedReadyConditionReason: cfsslissuerapi.IssuerReasonHealthCheckFailed,
+		},
+		"issuer-failing-healthchecker-check-tolerated": {
+			name: types.NamespacedName{Namespace: "ns1", Name: "issuer1"},
+			issuerObjects: []client.Object{
+				&cfsslissuerapi.Issuer{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "issuer1",
+						Namespace: "ns1",
+					},
+					Spec: cfsslissuerapi.IssuerSpec{
+						AuthSecretName: "issuer1-credentials",
+						Label:          "issuer1-label",
+						Profile:        "issuer1-profile",
+						HealthCheck: &cfsslissuerapi.HealthCheckConfig{
+							Interval:         &metav1.Duration{Duration: 5 * time.Minute},
+							FailureThreshold: 3,
+						},
+					},
+					Status: cfsslissuerapi.IssuerStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:   cfsslissuerapi.IssuerConditionReady,
+								Status: metav1.ConditionTrue,
+							},
+						},
+					},
+				},
+			},
+			secretObjects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "issuer1-credentials",
+						Namespace: "ns1",
+					},
+					Data: map[string][]byte{"key": []byte(validSecretKey)},
+				},
+			},
+			healthCheckerBuilder: func(*cfsslissuerapi.IssuerSpec, *signer.IssuerData) (signer.HealthChecker, error) {
+				return &fakeHealthChecker{errCheck: errors.New("simulated health check error")}, nil
+			},
+			expectedReadyConditionStatus: metav1.ConditionTrue,
+			expectedReadyConditionReason: cfsslissuerapi.IssuerReasonFailureTolerated,
+			expectedResult:               ctrl.Result{RequeueAfter: 5 * time.Minute},
+			expectedHealthCheck: &cfsslissuerapi.HealthCheckStatus{
+				LastCheckTime:       metav1.NewTime(fixedClockStart.Local()),
+				ConsecutiveFailures: 1,
+			},
+		},
+		"issuer-failing-healthchecker-check-threshold": {
 			name: types.NamespacedName{Namespace: "ns1", Name: "issuer1"},
 			issuerObjects: []client.Object{
 				&cfsslissuerapi.Issuer{
@@ -63,14 +1368,20 @@ func TestIssuerReconcile(t *testing.T) {
 						AuthSecretName: "issuer1-credentials",
 						Label:          "issuer1-label",
 						Profile:        "issuer1-profile",
+						HealthCheck: &cfsslissuerapi.HealthCheckConfig{
+							FailureThreshold: 3,
+						},
 					},
 					Status: cfsslissuerapi.IssuerStatus{
-						Conditions: []cfsslissuerapi.IssuerCondition{
+						Conditions: []metav1.Condition{
 							{
 								Type:   cfsslissuerapi.IssuerConditionReady,
-								Status: cfsslissuerapi.ConditionUnknown,
+								Status: metav1.ConditionTrue,
 							},
 						},
+						HealthCheck: &cfsslissuerapi.HealthCheckStatus{
+							ConsecutiveFailures: 2,
+						},
 					},
 				},
 			},
@@ -83,59 +1394,156 @@ func TestIssuerReconcile(t *testing.T) {
 					Data: map[string][]byte{"key": []byte(validSecretKey)},
 				},
 			},
-			healthCheckerBuilder: func(*cfsslissuerapi.IssuerSpec, map[string][]byte) (signer.HealthChecker, error) {
-				return &fakeHealthChecker{}, nil
+			healthCheckerBuilder: func(*cfsslissuerapi.IssuerSpec, *signer.IssuerData) (signer.HealthChecker, error) {
+				return &fakeHealthChecker{errCheck: errors.New("simulated health check error")}, nil
+			},
+			expectedError:                errHealthCheckerCheck,
+			expectedReadyConditionStatus: metav1.ConditionFalse,
+			expectedReadyConditionReason: cfsslissuerapi.IssuerReasonHealthCheckFailed,
+			expectedHealthCheck: &cfsslissuerapi.HealthCheckStatus{
+				LastCheckTime:       metav1.NewTime(fixedClockStart.Local()),
+				ConsecutiveFailures: 3,
 			},
-			expectedReadyConditionStatus: cfsslissuerapi.ConditionTrue,
-			expectedResult:               ctrl.Result{RequeueAfter: defaultHealthCheckInterval},
 		},
-		"success-clusterissuer": {
-			kind: "ClusterIssuer",
-			name: types.NamespacedName{Name: "clusterissuer1"},
+		"issuer-recovering": {
+			kind: "Issuer",
+			name: types.NamespacedName{Namespace: "ns1", Name: "issuer1"},
 			issuerObjects: []client.Object{
-				&cfsslissuerapi.ClusterIssuer{
+				&cfsslissuerapi.Issuer{
 					ObjectMeta: metav1.ObjectMeta{
-						Name: "clusterissuer1",
+						Name:      "issuer1",
+						Namespace: "ns1",
 					},
 					Spec: cfsslissuerapi.IssuerSpec{
-						AuthSecretName: "clusterissuer1-credentials",
-						Label:          "clusterissuer1-label",
-						Profile:        "clusterissuer1-profile",
+						AuthSecretName: "issuer1-credentials",
+						Label:          "issuer1-label",
+						Profile:        "issuer1-profile",
+						HealthCheck: &cfsslissuerapi.HealthCheckConfig{
+							SuccessThreshold: 2,
+						},
 					},
 					Status: cfsslissuerapi.IssuerStatus{
-						Conditions: []cfsslissuerapi.IssuerCondition{
+						Conditions: []metav1.Condition{
 							{
 								Type:   cfsslissuerapi.IssuerConditionReady,
-								Status: cfsslissuerapi.ConditionUnknown,
+								Status: metav1.ConditionFalse,
 							},
 						},
+						HealthCheck: &cfsslissuerapi.HealthCheckStatus{
+							ConsecutiveFailures: 4,
+						},
 					},
 				},
 			},
 			secretObjects: []client.Object{
 				&corev1.Secret{
 					ObjectMeta: metav1.ObjectMeta{
-						Name:      "clusterissuer1-credentials",
-						Namespace: "kube-system",
+						Name:      "issuer1-credentials",
+						Namespace: "ns1",
 					},
 					Data: map[string][]byte{"key": []byte(validSecretKey)},
 				},
 			},
-			healthCheckerBuilder: func(*cfsslissuerapi.IssuerSpec, map[string][]byte) (signer.HealthChecker, error) {
+			healthCheckerBuilder: func(*cfsslissuerapi.IssuerSpec, *signer.IssuerData) (signer.HealthChecker, error) {
 				return &fakeHealthChecker{}, nil
 			},
-			clusterResourceNamespace:     "kube-system",
-			expectedReadyConditionStatus: cfsslissuerapi.ConditionTrue,
-			expectedResult:               ctrl.Result{RequeueAfter: defaultHealthCheckInterval},
+			expectedReadyConditionStatus: metav1.ConditionFalse,
+			expectedReadyConditionReason: cfsslissuerapi.IssuerReasonRecovering,
+			expectedHealthCheck: &cfsslissuerapi.HealthCheckStatus{
+				LastCheckTime:        metav1.NewTime(fixedClockStart.Local()),
+				ConsecutiveSuccesses: 1,
+			},
+			expectedResult: ctrl.Result{RequeueAfter: defaultHealthCheckInterval},
 		},
-		"issuer-kind-unrecognised": {
-			kind: "UnrecognizedType",
+		"success-issuer-recovered": {
+			kind: "Issuer",
 			name: types.NamespacedName{Namespace: "ns1", Name: "issuer1"},
+			issuerObjects: []client.Object{
+				&cfsslissuerapi.Issuer{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "issuer1",
+						Namespace: "ns1",
+					},
+					Spec: cfsslissuerapi.IssuerSpec{
+						AuthSecretName: "issuer1-credentials",
+						Label:          "issuer1-label",
+						Profile:        "issuer1-profile",
+						HealthCheck: &cfsslissuerapi.HealthCheckConfig{
+							SuccessThreshold: 2,
+						},
+					},
+					Status: cfsslissuerapi.IssuerStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:   cfsslissuerapi.IssuerConditionReady,
+								Status: metav1.ConditionFalse,
+							},
+						},
+						HealthCheck: &cfsslissuerapi.HealthCheckStatus{
+							ConsecutiveSuccesses: 1,
+						},
+					},
+				},
+			},
+			secretObjects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "issuer1-credentials",
+						Namespace: "ns1",
+					},
+					Data: map[string][]byte{"key": []byte(validSecretKey)},
+				},
+			},
+			healthCheckerBuilder: func(*cfsslissuerapi.IssuerSpec, *signer.IssuerData) (signer.HealthChecker, error) {
+				return &fakeHealthChecker{}, nil
+			},
+			expectedReadyConditionStatus: metav1.ConditionTrue,
+			expectedHealthCheck: &cfsslissuerapi.HealthCheckStatus{
+				LastCheckTime:        metav1.NewTime(fixedClockStart.Local()),
+				ConsecutiveSuccesses: 2,
+			},
+			expectedResult: ctrl.Result{RequeueAfter: defaultHealthCheckInterval},
 		},
-		"issuer-not-found": {
+		"issuer-failing-healthchecker-unavailable": {
 			name: types.NamespacedName{Namespace: "ns1", Name: "issuer1"},
+			issuerObjects: []client.Object{
+				&cfsslissuerapi.Issuer{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "issuer1",
+						Namespace: "ns1",
+					},
+					Spec: cfsslissuerapi.IssuerSpec{
+						AuthSecretName: "issuer1-credentials",
+						Label:          "issuer1-label",
+						Profile:        "issuer1-profile",
+					},
+					Status: cfsslissuerapi.IssuerStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:   cfsslissuerapi.IssuerConditionReady,
+								Status: metav1.ConditionUnknown,
+							},
+						},
+					},
+				},
+			},
+			secretObjects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "issuer1-credentials",
+						Namespace: "ns1",
+					},
+					Data: map[string][]byte{"key": []byte(validSecretKey)},
+				},
+			},
+			healthCheckerBuilder: func(*cfsslissuerapi.IssuerSpec, *signer.IssuerData) (signer.HealthChecker, error) {
+				return &fakeHealthChecker{errCheck: fmt.Errorf("%w: simulated health check error", signer.ErrUnavailable)}, nil
+			},
+			expectedError:                errHealthCheckerCheck,
+			expectedReadyConditionStatus: metav1.ConditionFalse,
+			expectedReadyConditionReason: cfsslissuerapi.IssuerReasonCFSSLUnavailable,
 		},
-		"issuer-missing-ready-condition": {
+		"issuer-failing-healthchecker-label-rejected": {
 			name: types.NamespacedName{Namespace: "ns1", Name: "issuer1"},
 			issuerObjects: []client.Object{
 				&cfsslissuerapi.Issuer{
@@ -143,11 +1551,38 @@ func TestIssuerReconcile(t *testing.T) {
 						Name:      "issuer1",
 						Namespace: "ns1",
 					},
+					Spec: cfsslissuerapi.IssuerSpec{
+						AuthSecretName: "issuer1-credentials",
+						Label:          "issuer1-label",
+						Profile:        "issuer1-profile",
+					},
+					Status: cfsslissuerapi.IssuerStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:   cfsslissuerapi.IssuerConditionReady,
+								Status: metav1.ConditionUnknown,
+							},
+						},
+					},
+				},
+			},
+			secretObjects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "issuer1-credentials",
+						Namespace: "ns1",
+					},
+					Data: map[string][]byte{"key": []byte(validSecretKey)},
 				},
 			},
-			expectedReadyConditionStatus: cfsslissuerapi.ConditionUnknown,
+			healthCheckerBuilder: func(*cfsslissuerapi.IssuerSpec, *signer.IssuerData) (signer.HealthChecker, error) {
+				return &fakeHealthChecker{errCheck: fmt.Errorf("%w: simulated health check error", signer.ErrLabelRejected)}, nil
+			},
+			expectedError:                errHealthCheckerCheck,
+			expectedReadyConditionStatus: metav1.ConditionFalse,
+			expectedReadyConditionReason: cfsslissuerapi.IssuerReasonLabelRejected,
 		},
-		"issuer-missing-secret": {
+		"issuer-failing-healthchecker-endpoints": {
 			name: types.NamespacedName{Namespace: "ns1", Name: "issuer1"},
 			issuerObjects: []client.Object{
 				&cfsslissuerapi.Issuer{
@@ -156,24 +1591,50 @@ func TestIssuerReconcile(t *testing.T) {
 						Namespace: "ns1",
 					},
 					Spec: cfsslissuerapi.IssuerSpec{
+						URL:            "https://signer1.example.com,https://signer2.example.com",
 						AuthSecretName: "issuer1-credentials",
 						Label:          "issuer1-label",
 						Profile:        "issuer1-profile",
 					},
 					Status: cfsslissuerapi.IssuerStatus{
-						Conditions: []cfsslissuerapi.IssuerCondition{
+						Conditions: []metav1.Condition{
 							{
 								Type:   cfsslissuerapi.IssuerConditionReady,
-								Status: cfsslissuerapi.ConditionUnknown,
+								Status: metav1.ConditionTrue,
 							},
 						},
 					},
 				},
 			},
-			expectedError:                errGetAuthSecret,
-			expectedReadyConditionStatus: cfsslissuerapi.ConditionFalse,
+			secretObjects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "issuer1-credentials",
+						Namespace: "ns1",
+					},
+					Data: map[string][]byte{"key": []byte(validSecretKey)},
+				},
+			},
+			healthCheckerBuilder: func(*cfsslissuerapi.IssuerSpec, *signer.IssuerData) (signer.HealthChecker, error) {
+				return &fakeHealthChecker{
+					errCheck: errors.New("simulated health check error"),
+					endpoints: []cfsslissuerapi.EndpointStatus{
+						{URL: "https://signer1.example.com", LastError: "simulated health check error", ConsecutiveFailures: 3, CircuitOpen: true},
+						{URL: "https://signer2.example.com", LastError: "simulated health check error", ConsecutiveFailures: 1},
+					},
+				}, nil
+			},
+			expectedError:                   errHealthCheckerCheck,
+			expectedReadyConditionStatus:    metav1.ConditionFalse,
+			expectedDegradedConditionStatus: metav1.ConditionUnknown,
+			expectedDegradedConditionReason: cfsslissuerapi.IssuerReasonHealthCheckFailed,
+			expectedReadyConditionReason:    cfsslissuerapi.IssuerReasonHealthCheckFailed,
+			expectedEndpoints: []cfsslissuerapi.EndpointStatus{
+				{URL: "https://signer1.example.com", LastError: "simulated health check error", ConsecutiveFailures: 3, CircuitOpen: true},
+				{URL: "https://signer2.example.com", LastError: "simulated health check error", ConsecutiveFailures: 1},
+			},
 		},
-		"issuer-missing-secret-key": {
+		"issuer-failing-healthchecker-authentication": {
 			name: types.NamespacedName{Namespace: "ns1", Name: "issuer1"},
 			issuerObjects: []client.Object{
 				&cfsslissuerapi.Issuer{
@@ -185,27 +1646,38 @@ func TestIssuerReconcile(t *testing.T) {
 						AuthSecretName: "issuer1-credentials",
 						Label:          "issuer1-label",
 						Profile:        "issuer1-profile",
+						HealthCheck: &cfsslissuerapi.HealthCheckConfig{
+							Authenticated: true,
+						},
 					},
 					Status: cfsslissuerapi.IssuerStatus{
-						Conditions: []cfsslissuerapi.IssuerCondition{
+						Conditions: []metav1.Condition{
 							{
 								Type:   cfsslissuerapi.IssuerConditionReady,
-								Status: cfsslissuerapi.ConditionUnknown,
+								Status: metav1.ConditionTrue,
 							},
 						},
 					},
 				},
+			},
+			secretObjects: []client.Object{
 				&corev1.Secret{
 					ObjectMeta: metav1.ObjectMeta{
 						Name:      "issuer1-credentials",
 						Namespace: "ns1",
 					},
+					Data: map[string][]byte{"key": []byte(validSecretKey)},
 				},
 			},
-			expectedError:                errAuthSecretKeyMissing,
-			expectedReadyConditionStatus: cfsslissuerapi.ConditionFalse,
+			healthCheckerBuilder: func(*cfsslissuerapi.IssuerSpec, *signer.IssuerData) (signer.HealthChecker, error) {
+				return &fakeHealthChecker{errCheck: fmt.Errorf("%w: simulated invalid token", signer.ErrAuthenticationFailed)}, nil
+			},
+			expectedError:                       errHealthCheckerCheck,
+			expectedReadyConditionStatus:        metav1.ConditionFalse,
+			expectedAuthVerifiedConditionStatus: metav1.ConditionFalse,
+			expectedReadyConditionReason:        cfsslissuerapi.IssuerReasonAuthenticationFailed,
 		},
-		"issuer-failing-healthchecker-builder": {
+		"issuer-failing-healthchecker-authentication-previously-verified": {
 			name: types.NamespacedName{Namespace: "ns1", Name: "issuer1"},
 			issuerObjects: []client.Object{
 				&cfsslissuerapi.Issuer{
@@ -217,14 +1689,18 @@ func TestIssuerReconcile(t *testing.T) {
 						AuthSecretName: "issuer1-credentials",
 						Label:          "issuer1-label",
 						Profile:        "issuer1-profile",
+						HealthCheck: &cfsslissuerapi.HealthCheckConfig{
+							Authenticated: true,
+						},
 					},
 					Status: cfsslissuerapi.IssuerStatus{
-						Conditions: []cfsslissuerapi.IssuerCondition{
+						Conditions: []metav1.Condition{
 							{
 								Type:   cfsslissuerapi.IssuerConditionReady,
-								Status: cfsslissuerapi.ConditionUnknown,
+								Status: metav1.ConditionTrue,
 							},
 						},
+						LastAuthVerifiedTime: &metav1.Time{Time: fixedClockStart.Add(-time.Hour).Local()},
 					},
 				},
 			},
@@ -237,13 +1713,17 @@ func TestIssuerReconcile(t *testing.T) {
 					Data: map[string][]byte{"key": []byte(validSecretKey)},
 				},
 			},
-			healthCheckerBuilder: func(*cfsslissuerapi.IssuerSpec, map[string][]byte) (signer.HealthChecker, error) {
-				return nil, errors.New("simulated health checker builder error")
+			healthCheckerBuilder: func(*cfsslissuerapi.IssuerSpec, *signer.IssuerData) (signer.HealthChecker, error) {
+				return &fakeHealthChecker{errCheck: fmt.Errorf("%w: simulated invalid token", signer.ErrAuthenticationFailed)}, nil
 			},
-			expectedError:                errHealthCheckerBuilder,
-			expectedReadyConditionStatus: cfsslissuerapi.ConditionFalse,
+			expectedError:                       errHealthCheckerCheck,
+			expectedReadyConditionStatus:        metav1.ConditionFalse,
+			expectedAuthVerifiedConditionStatus: metav1.ConditionFalse,
+			expectedReadyConditionReason:        cfsslissuerapi.IssuerReasonAuthenticationFailed,
+			// The time the key was last accepted is kept
+			expectedLastAuthVerifiedTime: &metav1.Time{Time: fixedClockStart.Add(-time.Hour).Local()},
 		},
-		"issuer-failing-healthchecker-check": {
+		"issuer-failing-healthchecker-ca-pin": {
 			name: types.NamespacedName{Namespace: "ns1", Name: "issuer1"},
 			issuerObjects: []client.Object{
 				&cfsslissuerapi.Issuer{
@@ -255,12 +1735,15 @@ func TestIssuerReconcile(t *testing.T) {
 						AuthSecretName: "issuer1-credentials",
 						Label:          "issuer1-label",
 						Profile:        "issuer1-profile",
+						CAPins: &cfsslissuerapi.CAPins{
+							SPKISHA256: []string{"3f2a5c0d8e0e4b3c7f4e6a1b9d2c8e7f6a5b4c3d2e1f0a9b8c7d6e5f4a3b2c1d"},
+						},
 					},
 					Status: cfsslissuerapi.IssuerStatus{
-						Conditions: []cfsslissuerapi.IssuerCondition{
+						Conditions: []metav1.Condition{
 							{
 								Type:   cfsslissuerapi.IssuerConditionReady,
-								Status: cfsslissuerapi.ConditionUnknown,
+								Status: metav1.ConditionTrue,
 							},
 						},
 					},
@@ -275,17 +1758,19 @@ func TestIssuerReconcile(t *testing.T) {
 					Data: map[string][]byte{"key": []byte(validSecretKey)},
 				},
 			},
-			healthCheckerBuilder: func(*cfsslissuerapi.IssuerSpec, map[string][]byte) (signer.HealthChecker, error) {
-				return &fakeHealthChecker{errCheck: errors.New("simulated health check error")}, nil
+			healthCheckerBuilder: func(*cfsslissuerapi.IssuerSpec, *signer.IssuerData) (signer.HealthChecker, error) {
+				return &fakeHealthChecker{errCheck: fmt.Errorf("%w: simulated signer certificate", signer.ErrCAPinMismatch)}, nil
 			},
 			expectedError:                errHealthCheckerCheck,
-			expectedReadyConditionStatus: cfsslissuerapi.ConditionFalse,
+			expectedReadyConditionStatus: metav1.ConditionFalse,
+			expectedReadyConditionReason: cfsslissuerapi.IssuerReasonCAPinMismatch,
 		},
 	}
 
 	scheme := runtime.NewScheme()
 	require.NoError(t, cfsslissuerapi.AddToScheme(scheme))
 	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, cmapi.AddToScheme(scheme))
 
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -294,7 +1779,10 @@ func TestIssuerReconcile(t *testing.T) {
 				WithScheme(scheme).
 				WithObjects(tc.secretObjects...).
 				WithObjects(tc.issuerObjects...).
+				WithObjects(tc.certificateObjects...).
 				WithStatusSubresource(tc.issuerObjects...).
+				WithStatusSubresource(tc.certificateObjects...).
+				WithInterceptorFuncs(tc.interceptorFuncs).
 				Build()
 			if tc.kind == "" {
 				tc.kind = "Issuer"
@@ -305,6 +1793,7 @@ func TestIssuerReconcile(t *testing.T) {
 				Scheme:                   scheme,
 				HealthCheckerBuilder:     tc.healthCheckerBuilder,
 				ClusterResourceNamespace: tc.clusterResourceNamespace,
+				Clock:                    fixedClock,
 				recorder:                 eventRecorder,
 			}
 
@@ -363,6 +1852,10 @@ func TestIssuerReconcile(t *testing.T) {
 			require.NoError(t, err)
 
 			condition := issuerutil.GetReadyCondition(issuerStatusAfter)
+			assert.Equal(t, issuerAfter.GetGeneration(), issuerStatusAfter.ObservedGeneration, "unexpected observed generation")
+			if condition != nil {
+				assert.Equal(t, issuerAfter.GetGeneration(), condition.ObservedGeneration, "unexpected observed generation of the Ready condition")
+			}
 
 			if tc.expectedReadyConditionStatus != "" {
 				if assert.NotNilf(
@@ -371,12 +1864,64 @@ func TestIssuerReconcile(t *testing.T) {
 					"Ready condition was expected but not found: tc.expectedReadyConditionStatus == %v",
 					tc.expectedReadyConditionStatus,
 				) {
-					verifyIssuerReadyCondition(t, tc.expectedReadyConditionStatus, condition)
+					verifyIssuerReadyCondition(t, tc.expectedReadyConditionStatus, tc.expectedReadyConditionReason, condition)
 				}
 			} else {
 				assert.Nil(t, condition, "Unexpected Ready condition")
 			}
 
+			assert.Equal(t, tc.expectedEndpoints, issuerStatusAfter.Endpoints, "unexpected endpoint status")
+			assert.Equal(t, tc.expectedCA, issuerStatusAfter.CA, "unexpected CA status")
+			assert.Equal(t, tc.expectedAcceptedKey, issuerStatusAfter.AcceptedKey, "unexpected accepted key")
+			if tc.expectedDegradedConditionStatus != "" {
+				degraded := issuerutil.GetCondition(issuerStatusAfter, cfsslissuerapi.IssuerConditionDegraded)
+				if assert.NotNil(t, degraded, "Degraded condition was expected but not found") {
+					assert.Equal(t, tc.expectedDegradedConditionStatus, degraded.Status, "unexpected Degraded condition status")
+					assert.Equal(t, string(tc.expectedDegradedConditionReason), degraded.Reason, "unexpected Degraded condition reason")
+				}
+			}
+			authVerified := issuerutil.GetCondition(issuerStatusAfter, cfsslissuerapi.IssuerConditionAuthVerified)
+			if tc.expectedAuthVerifiedConditionStatus != "" {
+				if assert.NotNil(t, authVerified, "AuthVerified condition was expected but not found") {
+					assert.Equal(t, tc.expectedAuthVerifiedConditionStatus, authVerified.Status, "unexpected AuthVerified condition status")
+				}
+			} else {
+				assert.Nil(t, authVerified, "unexpected AuthVerified condition")
+			}
+			assert.Equal(t, tc.expectedLastAuthVerifiedTime, issuerStatusAfter.LastAuthVerifiedTime, "unexpected last auth verification")
+			if tc.expectedHealthCheck != nil {
+				assert.Equal(t, tc.expectedHealthCheck, issuerStatusAfter.HealthCheck, "unexpected health check status")
+			}
+			assert.Equal(t, tc.expectedLastCARotation, issuerStatusAfter.LastCARotation, "unexpected CA rotation")
+			var reissued []string
+			for _, obj := range tc.certificateObjects {
+				var crt cmapi.Certificate
+				require.NoError(t, fakeClient.Get(context.TODO(), client.ObjectKeyFromObject(obj), &crt))
+				if cmutil.CertificateHasCondition(&crt, cmapi.CertificateCondition{
+					Type:   cmapi.CertificateConditionIssuing,
+					Status: cmmeta.ConditionTrue,
+				}) {
+					reissued = append(reissued, crt.Name)
+					assert.Equal(t, tc.expectedCA.SHA256Fingerprint, crt.Annotations[cfsslissuerapi.CARotatedAnnotationKey])
+				}
+			}
+			assert.Equal(t, tc.expectedReissuedCertificates, reissued, "unexpected re-issued Certificates")
+			if reissuedCondition := issuerutil.GetCondition(issuerStatusAfter, cfsslissuerapi.IssuerConditionCertificatesReissued); tc.expectedCertificatesReissuedConditionStatus != "" {
+				if assert.NotNil(t, reissuedCondition, "CertificatesReissued condition was expected but not found") {
+					assert.Equal(t, tc.expectedCertificatesReissuedConditionStatus, reissuedCondition.Status, "unexpected CertificatesReissued condition status")
+					assert.Equal(t, string(tc.expectedCertificatesReissuedConditionReason), reissuedCondition.Reason, "unexpected CertificatesReissued condition reason")
+				}
+			} else {
+				assert.Nil(t, reissuedCondition, "unexpected CertificatesReissued condition")
+			}
+			if expiringSoon := issuerutil.GetCondition(issuerStatusAfter, cfsslissuerapi.IssuerConditionCAExpiringSoon); tc.expectedCAExpiringSoonConditionStatus != "" {
+				if assert.NotNil(t, expiringSoon, "CAExpiringSoon condition was expected but not found") {
+					assert.Equal(t, tc.expectedCAExpiringSoonConditionStatus, expiringSoon.Status, "unexpected CAExpiringSoon condition status")
+				}
+			} else {
+				assert.Nil(t, expiringSoon, "Unexpected CAExpiringSoon condition")
+			}
+
 			// Event checks
 			if condition != nil {
 				// The desired Event behaviour is as follows:
@@ -386,7 +1931,7 @@ func TestIssuerReconcile(t *testing.T) {
 				// * Event type should be Warning if the Reconcile failed (temporary error)
 				// * Event type should be warning if the condition status is failed (permanent error)
 				expectedEventType := corev1.EventTypeNormal
-				if reconcileErr != nil || condition.Status == cfsslissuerapi.ConditionFalse {
+				if reconcileErr != nil || condition.Status == metav1.ConditionFalse {
 					expectedEventType = corev1.EventTypeWarning
 				}
 				// If there was a Reconcile error, there will be a retry and
@@ -398,7 +1943,7 @@ func TestIssuerReconcile(t *testing.T) {
 				// Each Reconcile should only emit a single Event
 				assert.Equal(
 					t,
-					[]string{fmt.Sprintf("%s %s %s", expectedEventType, cfsslissuerapi.EventReasonIssuerReconciler, eventMessage)},
+					append(tc.expectedEvents, fmt.Sprintf("%s %s %s", expectedEventType, condition.Reason, eventMessage)),
 					actualEvents,
 					"expected a single event matching the condition",
 				)
@@ -409,6 +1954,290 @@ func TestIssuerReconcile(t *testing.T) {
 	}
 }
 
-func verifyIssuerReadyCondition(t *testing.T, status cfsslissuerapi.ConditionStatus, condition *cfsslissuerapi.IssuerCondition) {
+func TestIssuersForSecret(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, cfsslissuerapi.AddToScheme(scheme))
+	issuerWithSecret := func(obj client.Object, secretName string) client.Object {
+		spec, _, err := issuerutil.GetSpecAndStatus(obj)
+		require.NoError(t, err)
+		spec.AuthSecretName = secretName
+		return obj
+	}
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			issuerWithSecret(&cfsslissuerapi.Issuer{ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "issuer1"}}, "credentials"),
+			issuerWithSecret(&cfsslissuerapi.Issuer{ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "issuer2"}}, "other-credentials"),
+			issuerWithSecret(&cfsslissuerapi.Issuer{ObjectMeta: metav1.ObjectMeta{Namespace: "ns2", Name: "issuer3"}}, "credentials"),
+			issuerWithSecret(&cfsslissuerapi.ClusterIssuer{ObjectMeta: metav1.ObjectMeta{Name: "clusterissuer1"}}, "credentials"),
+		).
+		WithIndex(&cfsslissuerapi.Issuer{}, authSecretNameField, authSecretName).
+		WithIndex(&cfsslissuerapi.ClusterIssuer{}, authSecretNameField, authSecretName).
+		Build()
+	secret := func(namespace string) *corev1.Secret {
+		return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "credentials"}}
+	}
+
+	type testCase struct {
+		kind             string
+		secret           *corev1.Secret
+		expectedRequests []reconcile.Request
+	}
+	tests := map[string]testCase{
+		"issuer": {
+			kind:   "Issuer",
+			secret: secret("ns1"),
+			expectedRequests: []reconcile.Request{
+				{NamespacedName: types.NamespacedName{Namespace: "ns1", Name: "issuer1"}},
+			},
+		},
+		"issuer-unused-secret": {
+			kind:   "Issuer",
+			secret: secret("ns3"),
+		},
+		"clusterissuer": {
+			kind:   "ClusterIssuer",
+			secret: secret("kube-system"),
+			expectedRequests: []reconcile.Request{
+				{NamespacedName: types.NamespacedName{Name: "clusterissuer1"}},
+			},
+		},
+		"clusterissuer-other-namespace": {
+			kind:   "ClusterIssuer",
+			secret: secret("ns1"),
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			controller := IssuerReconciler{
+				Kind:                     tc.kind,
+				Client:                   fakeClient,
+				Scheme:                   scheme,
+				ClusterResourceNamespace: "kube-system",
+			}
+			requests := controller.issuersForSecret(context.TODO(), tc.secret)
+			assert.Equal(t, tc.expectedRequests, requests)
+		})
+	}
+}
+
+// TestIssuerHealthCheckInterval checks that the status update of a health check
+// does not trigger another one before the interval has passed.
+func TestIssuerHealthCheckInterval(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, cfsslissuerapi.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+	issuer := &cfsslissuerapi.Issuer{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "issuer1", Generation: 1},
+		Spec: cfsslissuerapi.IssuerSpec{
+			AuthSecretName: "issuer1-credentials",
+			Label:          "issuer1-label",
+			HealthCheck: &cfsslissuerapi.HealthCheckConfig{
+				Interval: &metav1.Duration{Duration: 5 * time.Minute},
+			},
+		},
+		Status: cfsslissuerapi.IssuerStatus{
+			Conditions: []metav1.Condition{{Type: cfsslissuerapi.IssuerConditionReady, Status: metav1.ConditionTrue}},
+		},
+	}
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(issuer, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "issuer1-credentials"},
+			Data:       map[string][]byte{"key": []byte(validSecretKey)},
+		}).
+		WithStatusSubresource(issuer).
+		Build()
+	checker := &fakeHealthChecker{}
+	controller := IssuerReconciler{
+		Kind:   "Issuer",
+		Client: fakeClient,
+		Scheme: scheme,
+		HealthCheckerBuilder: func(*cfsslissuerapi.IssuerSpec, *signer.IssuerData) (signer.HealthChecker, error) {
+			return checker, nil
+		},
+		Clock:    fixedClock,
+		recorder: record.NewFakeRecorder(100),
+	}
+
+	var before cfsslissuerapi.Issuer
+	require.NoError(t, fakeClient.Get(context.TODO(), client.ObjectKeyFromObject(issuer), &before))
+	result, err := controller.Reconcile(context.TODO(), reconcile.Request{NamespacedName: client.ObjectKeyFromObject(issuer)})
+	require.NoError(t, err)
+	assert.Equal(t, ctrl.Result{RequeueAfter: 5 * time.Minute}, result)
+	assert.Equal(t, 1, checker.checks)
+
+	var after cfsslissuerapi.Issuer
+	require.NoError(t, fakeClient.Get(context.TODO(), client.ObjectKeyFromObject(issuer), &after))
+	require.NotEqual(t, before.ResourceVersion, after.ResourceVersion, "status was not updated")
+	assert.False(t, issuerChanged.Update(event.UpdateEvent{ObjectOld: &before, ObjectNew: &after}), "status update triggers another health check")
+
+	changed := after.DeepCopy()
+	changed.Generation++
+	assert.True(t, issuerChanged.Update(event.UpdateEvent{ObjectOld: &after, ObjectNew: changed}), "spec change does not trigger a health check")
+}
+
+func TestIssuerHealthCheckMetricsOnError(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, cfsslissuerapi.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+	issuer := &cfsslissuerapi.Issuer{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "metrics-issuer"},
+		Spec: cfsslissuerapi.IssuerSpec{
+			AuthSecretName: "metrics-issuer-credentials",
+			Label:          "issuer1-label",
+		},
+		Status: cfsslissuerapi.IssuerStatus{
+			Conditions: []metav1.Condition{{Type: cfsslissuerapi.IssuerConditionReady, Status: metav1.ConditionTrue}},
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "metrics-issuer-credentials"},
+		Data:       map[string][]byte{"key": []byte(validSecretKey)},
+	}
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(issuer, secret).
+		WithStatusSubresource(issuer).
+		Build()
+	controller := IssuerReconciler{
+		Kind:   "Issuer",
+		Client: fakeClient,
+		Scheme: scheme,
+		HealthCheckerBuilder: func(*cfsslissuerapi.IssuerSpec, *signer.IssuerData) (signer.HealthChecker, error) {
+			return &fakeHealthChecker{endpoints: []cfsslissuerapi.EndpointStatus{{URL: "https://cfssl.example.com", Healthy: true}}}, nil
+		},
+		Clock:    fixedClock,
+		recorder: record.NewFakeRecorder(100),
+	}
+	issuerName := client.ObjectKeyFromObject(issuer)
+	t.Cleanup(func() { deleteIssuerMetrics("Issuer", issuerName) })
+
+	_, err := controller.Reconcile(context.TODO(), reconcile.Request{NamespacedName: issuerName})
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, metricValue(t, healthCheckStatus.WithLabelValues("Issuer", "ns1", "metrics-issuer", "issuer1-label", "", "https://cfssl.example.com", string(cfsslissuerapi.IssuerReasonHealthy))))
+
+	// The health check cannot run without the Secret, which is reported as
+	// unhealthy with the reason of the Ready condition
+	require.NoError(t, fakeClient.Delete(context.TODO(), secret))
+	_, err = controller.Reconcile(context.TODO(), reconcile.Request{NamespacedName: issuerName})
+	require.ErrorIs(t, err, errGetAuthSecret)
+	assert.Equal(t, 0.0, metricValue(t, healthCheckStatus.WithLabelValues("Issuer", "ns1", "metrics-issuer", "issuer1-label", "", "", string(cfsslissuerapi.IssuerReasonAuthSecretUnavailable))))
+	assert.Equal(t, 1, seriesCount(t, healthCheckStatus, "metrics-issuer"), "series of the last health check are kept")
+}
+
+func TestIssuerCAPinMismatchWithHealthyFallback(t *testing.T) {
+	// The primary label signs with testCA, the fallback label with the
+	// pinned otherCA
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Label string `json:"label"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		certificate := testCA
+		if req.Label == "fallback-label" {
+			certificate = otherCA
+		}
+		resp, err := json.Marshal(map[string]interface{}{
+			"success":  true,
+			"result":   map[string]interface{}{"certificate": string(certificate), "usages": []string{"signing"}, "expiry": "8760h"},
+			"errors":   []string{},
+			"messages": []string{},
+		})
+		require.NoError(t, err)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(resp)
+	}))
+	t.Cleanup(srv.Close)
+	block, _ := pem.Decode(otherCA)
+	otherCAFingerprint := sha256.Sum256(block.Bytes)
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, cfsslissuerapi.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+	issuer := &cfsslissuerapi.Issuer{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "pinned-issuer"},
+		Spec: cfsslissuerapi.IssuerSpec{
+			URL:            srv.URL,
+			AuthSecretName: "pinned-issuer-credentials",
+			Label:          "issuer1-label",
+			FallbackLabels: []string{"fallback-label"},
+			CAPins:         &cfsslissuerapi.CAPins{CertificateSHA256: []string{hex.EncodeToString(otherCAFingerprint[:])}},
+			HealthCheck:    &cfsslissuerapi.HealthCheckConfig{FailureThreshold: 3},
+		},
+		Status: cfsslissuerapi.IssuerStatus{
+			Conditions: []metav1.Condition{{Type: cfsslissuerapi.IssuerConditionReady, Status: metav1.ConditionTrue}},
+		},
+	}
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(issuer, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "pinned-issuer-credentials"},
+			Data:       map[string][]byte{"key": []byte(validSecretKey)},
+		}).
+		WithStatusSubresource(issuer).
+		Build()
+	controller := IssuerReconciler{
+		Kind:                 "Issuer",
+		Client:               fakeClient,
+		Scheme:               scheme,
+		HealthCheckerBuilder: signer.NewCfsslHealthChecker,
+		Clock:                fixedClock,
+		recorder:             record.NewFakeRecorder(100),
+	}
+	issuerName := client.ObjectKeyFromObject(issuer)
+	t.Cleanup(func() {
+		deleteIssuerMetrics("Issuer", issuerName)
+		signer.ForgetIssuer(issuerKey("Issuer", issuerName))
+	})
+
+	// The mismatch is neither made up for by the fallback label nor
+	// tolerated by the failure threshold
+	_, err := controller.Reconcile(context.TODO(), reconcile.Request{NamespacedName: issuerName})
+	assertErrorIs(t, signer.ErrCAPinMismatch, err)
+
+	var after cfsslissuerapi.Issuer
+	require.NoError(t, fakeClient.Get(context.TODO(), issuerName, &after))
+	verifyIssuerReadyCondition(t, metav1.ConditionFalse, cfsslissuerapi.IssuerReasonCAPinMismatch, issuerutil.GetReadyCondition(&after.Status))
+	require.Len(t, after.Status.Labels, 2)
+	assert.False(t, after.Status.Labels[0].Healthy)
+	assert.True(t, after.Status.Labels[1].Healthy)
+	assert.Nil(t, after.Status.CA)
+}
+
+// testCertificate returns a cert-manager Certificate referencing an issuer. If
+// rotatedTo is set, the Certificate was already re-issued for the signer
+// certificate with that fingerprint.
+func testCertificate(namespace, name, issuerKind, issuerName, rotatedTo string) *cmapi.Certificate {
+	crt := &cmapi.Certificate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: cmapi.CertificateSpec{
+			SecretName: name,
+			IssuerRef: cmmeta.ObjectReference{
+				Group: cfsslissuerapi.GroupVersion.Group,
+				Kind:  issuerKind,
+				Name:  issuerName,
+			},
+		},
+	}
+	if rotatedTo != "" {
+		crt.Annotations = map[string]string{cfsslissuerapi.CARotatedAnnotationKey: rotatedTo}
+	}
+	return crt
+}
+
+func verifyIssuerReadyCondition(t *testing.T, status metav1.ConditionStatus, reason cfsslissuerapi.IssuerConditionReason, condition *metav1.Condition) {
 	assert.Equal(t, status, condition.Status, "unexpected condition status")
+	if reason == "" {
+		switch status {
+		case metav1.ConditionTrue:
+			reason = cfsslissuerapi.IssuerReasonHealthy
+		case metav1.ConditionUnknown:
+			reason = cfsslissuerapi.IssuerReasonPending
+		}
+	}
+	assert.Equal(t, string(reason), condition.Reason, "unexpected condition reason")
 }
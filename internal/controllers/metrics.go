@@ -0,0 +1,139 @@
+/*
+Copyright 2021 The Wikimedia Foundation, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	cfsslissuerapi "gerrit.wikimedia.org/r/operations/software/cfssl-issuer/api/v1alpha1"
+	"gerrit.wikimedia.org/r/operations/software/cfssl-issuer/internal/issuer/signer"
+)
+
+const metricsNamespace = "cfssl_issuer"
+
+// Labels of every metric. The namespace is empty for ClusterIssuers, the
+// endpoint is empty if no CFSSL API endpoint answered.
+var issuerMetricLabels = []string{"issuer_kind", "issuer_namespace", "issuer_name", "label", "profile", "endpoint"}
+
+var (
+	signDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "sign_duration_seconds",
+		Help:      "Duration of signing requests, including the fallback to other labels.",
+		Buckets:   prometheus.ExponentialBuckets(0.05, 2, 10),
+	}, issuerMetricLabels)
+
+	signResults = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "sign_results_total",
+		Help:      "Number of signing requests by outcome (success or failure) and class of the error.",
+	}, append(issuerMetricLabels[:len(issuerMetricLabels):len(issuerMetricLabels)], "outcome", "error_class"))
+
+	healthCheckStatus = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "health_check_status",
+		Help:      "Whether the endpoint was healthy in the last health check, 1 if it was and 0 if not. Series with an empty endpoint report the health of a label. The reason is that of the outcome of the health check.",
+	}, append(issuerMetricLabels[:len(issuerMetricLabels):len(issuerMetricLabels)], "reason"))
+
+	caNotAfter = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "ca_not_after_timestamp_seconds",
+		Help:      "End of the validity of the signer certificate of a label, as seen in the last health check, in seconds since the epoch.",
+	}, issuerMetricLabels)
+)
+
+func init() {
+	metrics.Registry.MustRegister(signDuration, signResults, healthCheckStatus, caNotAfter)
+}
+
+// observeSign records the duration and outcome of a signing request. result
+// may be nil if the request failed before a label was tried.
+func observeSign(kind string, issuerName types.NamespacedName, issuerSpec *cfsslissuerapi.IssuerSpec, req *signer.SignRequest, result *signer.SignResult, duration time.Duration, err error) {
+	label, profile, endpoint := req.Label, req.Profile, ""
+	if label == "" {
+		label = issuerSpec.Label
+	}
+	if profile == "" {
+		profile = issuerSpec.Profile
+	}
+	if result != nil {
+		if result.Label != "" {
+			label = result.Label
+		}
+		endpoint = result.Endpoint
+	}
+	outcome := "success"
+	if err != nil {
+		outcome = "failure"
+	}
+	signDuration.WithLabelValues(kind, issuerName.Namespace, issuerName.Name, label, profile, endpoint).Observe(duration.Seconds())
+	signResults.WithLabelValues(kind, issuerName.Namespace, issuerName.Name, label, profile, endpoint, outcome, signer.ErrorClass(err)).Inc()
+}
+
+// recordHealthCheckMetrics replaces the health check metrics of an issuer with
+// the result of its last health check. The endpoints are checked with the
+// first label only.
+func recordHealthCheckMetrics(kind string, issuerName types.NamespacedName, issuerSpec *cfsslissuerapi.IssuerSpec, result *signer.HealthCheckResult, reason cfsslissuerapi.IssuerConditionReason) {
+	issuerLabels := prometheus.Labels{"issuer_kind": kind, "issuer_namespace": issuerName.Namespace, "issuer_name": issuerName.Name}
+	healthCheckStatus.DeletePartialMatch(issuerLabels)
+	caNotAfter.DeletePartialMatch(issuerLabels)
+
+	for _, endpoint := range result.Endpoints {
+		healthCheckStatus.WithLabelValues(kind, issuerName.Namespace, issuerName.Name, issuerSpec.Label, issuerSpec.Profile, endpoint.URL, string(reason)).Set(boolValue(endpoint.Healthy))
+	}
+	for _, label := range result.Labels {
+		healthCheckStatus.WithLabelValues(kind, issuerName.Namespace, issuerName.Name, label.Label, issuerSpec.Profile, "", string(reason)).Set(boolValue(label.Healthy))
+	}
+	for _, s := range result.Signers {
+		caNotAfter.WithLabelValues(kind, issuerName.Namespace, issuerName.Name, s.Label, issuerSpec.Profile, s.Endpoint).Set(float64(s.NotAfter.Unix()))
+	}
+}
+
+// recordFailedHealthCheckMetrics reports all labels of an issuer as unhealthy
+// if its health check could not be run, like when the auth Secret is missing.
+// The CA validity last seen is kept.
+func recordFailedHealthCheckMetrics(kind string, issuerName types.NamespacedName, issuerSpec *cfsslissuerapi.IssuerSpec, reason cfsslissuerapi.IssuerConditionReason) {
+	healthCheckStatus.DeletePartialMatch(prometheus.Labels{"issuer_kind": kind, "issuer_namespace": issuerName.Namespace, "issuer_name": issuerName.Name})
+
+	labels := append([]string{issuerSpec.Label}, issuerSpec.FallbackLabels...)
+	if issuerSpec.Rollout != nil {
+		labels = append(labels, issuerSpec.Rollout.Label)
+	}
+	for _, label := range labels {
+		healthCheckStatus.WithLabelValues(kind, issuerName.Namespace, issuerName.Name, label, issuerSpec.Profile, "", string(reason)).Set(0)
+	}
+}
+
+// deleteIssuerMetrics removes all series of a deleted issuer.
+func deleteIssuerMetrics(kind string, issuerName types.NamespacedName) {
+	issuerLabels := prometheus.Labels{"issuer_kind": kind, "issuer_namespace": issuerName.Namespace, "issuer_name": issuerName.Name}
+	signDuration.DeletePartialMatch(issuerLabels)
+	signResults.DeletePartialMatch(issuerLabels)
+	healthCheckStatus.DeletePartialMatch(issuerLabels)
+	caNotAfter.DeletePartialMatch(issuerLabels)
+}
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,142 @@
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+
+	cfsslissuerapi "gerrit.wikimedia.org/r/operations/software/cfssl-issuer/api/v1alpha1"
+	"gerrit.wikimedia.org/r/operations/software/cfssl-issuer/internal/issuer/signer"
+)
+
+// metricValue returns the value of a counter or gauge, or the number of
+// observations of a histogram.
+func metricValue(t *testing.T, m prometheus.Metric) float64 {
+	var out dto.Metric
+	require.NoError(t, m.Write(&out))
+	switch {
+	case out.Counter != nil:
+		return out.Counter.GetValue()
+	case out.Gauge != nil:
+		return out.Gauge.GetValue()
+	case out.Histogram != nil:
+		return float64(out.Histogram.GetSampleCount())
+	}
+	t.Fatalf("unsupported metric %v", m.Desc())
+	return 0
+}
+
+// seriesCount returns the number of series of a collector for an issuer, as
+// the controller tests record metrics as well.
+func seriesCount(t *testing.T, c prometheus.Collector, issuerName string) int {
+	ch := make(chan prometheus.Metric)
+	go func() {
+		c.Collect(ch)
+		close(ch)
+	}()
+	var n int
+	for m := range ch {
+		var out dto.Metric
+		require.NoError(t, m.Write(&out))
+		for _, label := range out.Label {
+			if label.GetName() == "issuer_name" && label.GetValue() == issuerName {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+func TestObserveSign(t *testing.T) {
+	issuerName := types.NamespacedName{Namespace: "ns1", Name: "observe-sign"}
+	issuerSpec := &cfsslissuerapi.IssuerSpec{Label: "signer1-label", Profile: "signer1-profile"}
+	t.Cleanup(func() { deleteIssuerMetrics("Issuer", issuerName) })
+
+	// Defaults are taken from the IssuerSpec before a label was tried
+	observeSign("Issuer", issuerName, issuerSpec, &signer.SignRequest{}, nil, time.Second, signer.ErrInvalidCSR)
+	observeSign("Issuer", issuerName, issuerSpec, &signer.SignRequest{Label: "signer2-label", Profile: "other-profile"},
+		&signer.SignResult{Label: "signer1-label", Endpoint: "https://cfssl.example.com"}, time.Second, nil)
+	observeSign("Issuer", issuerName, issuerSpec, &signer.SignRequest{Label: "signer2-label", Profile: "other-profile"},
+		&signer.SignResult{Label: "signer1-label", Endpoint: "https://cfssl.example.com"}, time.Second, nil)
+
+	assert.Equal(t, 1.0, metricValue(t, signResults.WithLabelValues("Issuer", "ns1", "observe-sign", "signer1-label", "signer1-profile", "", "failure", "invalid_csr")))
+	assert.Equal(t, 2.0, metricValue(t, signResults.WithLabelValues("Issuer", "ns1", "observe-sign", "signer1-label", "other-profile", "https://cfssl.example.com", "success", "")))
+	assert.Equal(t, 2.0, metricValue(t, signDuration.WithLabelValues("Issuer", "ns1", "observe-sign", "signer1-label", "other-profile", "https://cfssl.example.com").(prometheus.Metric)))
+	assert.Equal(t, 2, seriesCount(t, signResults, issuerName.Name))
+
+	deleteIssuerMetrics("Issuer", issuerName)
+	assert.Zero(t, seriesCount(t, signResults, issuerName.Name))
+	assert.Zero(t, seriesCount(t, signDuration, issuerName.Name))
+}
+
+func TestRecordHealthCheckMetrics(t *testing.T) {
+	issuerName := types.NamespacedName{Name: "record-health-check"}
+	issuerSpec := &cfsslissuerapi.IssuerSpec{Label: "signer1-label", Profile: "signer1-profile"}
+	t.Cleanup(func() { deleteIssuerMetrics("ClusterIssuer", issuerName) })
+	notAfter := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	recordHealthCheckMetrics("ClusterIssuer", issuerName, issuerSpec, &signer.HealthCheckResult{
+		Endpoints: []cfsslissuerapi.EndpointStatus{
+			{URL: "https://cfssl1.example.com", Healthy: true},
+			{URL: "https://cfssl2.example.com", Healthy: false},
+		},
+		Labels: []cfsslissuerapi.LabelStatus{
+			{Label: "signer1-label", Healthy: true},
+			{Label: "signer2-label", Healthy: false},
+		},
+		Signers: []signer.SignerStatus{
+			{Label: "signer1-label", Endpoint: "https://cfssl1.example.com", NotAfter: notAfter},
+		},
+	}, cfsslissuerapi.IssuerReasonHealthy)
+	assert.Equal(t, 1.0, metricValue(t, healthCheckStatus.WithLabelValues("ClusterIssuer", "", "record-health-check", "signer1-label", "signer1-profile", "https://cfssl1.example.com", "Healthy")))
+	assert.Equal(t, 0.0, metricValue(t, healthCheckStatus.WithLabelValues("ClusterIssuer", "", "record-health-check", "signer1-label", "signer1-profile", "https://cfssl2.example.com", "Healthy")))
+	assert.Equal(t, 1.0, metricValue(t, healthCheckStatus.WithLabelValues("ClusterIssuer", "", "record-health-check", "signer1-label", "signer1-profile", "", "Healthy")))
+	assert.Equal(t, 0.0, metricValue(t, healthCheckStatus.WithLabelValues("ClusterIssuer", "", "record-health-check", "signer2-label", "signer1-profile", "", "Healthy")))
+	assert.Equal(t, float64(notAfter.Unix()), metricValue(t, caNotAfter.WithLabelValues("ClusterIssuer", "", "record-health-check", "signer1-label", "signer1-profile", "https://cfssl1.example.com")))
+	assert.Equal(t, 4, seriesCount(t, healthCheckStatus, issuerName.Name))
+	assert.Equal(t, 1, seriesCount(t, caNotAfter, issuerName.Name))
+
+	// Series of endpoints and labels no longer reported are removed
+	recordHealthCheckMetrics("ClusterIssuer", issuerName, issuerSpec, &signer.HealthCheckResult{
+		Labels: []cfsslissuerapi.LabelStatus{
+			{Label: "signer1-label", Healthy: false, LastError: "connection refused"},
+		},
+	}, cfsslissuerapi.IssuerReasonCFSSLUnavailable)
+	assert.Equal(t, 1, seriesCount(t, healthCheckStatus, issuerName.Name))
+	assert.Equal(t, 0.0, metricValue(t, healthCheckStatus.WithLabelValues("ClusterIssuer", "", "record-health-check", "signer1-label", "signer1-profile", "", "CFSSLUnavailable")))
+	assert.Zero(t, seriesCount(t, caNotAfter, issuerName.Name))
+
+	deleteIssuerMetrics("ClusterIssuer", issuerName)
+	assert.Zero(t, seriesCount(t, healthCheckStatus, issuerName.Name))
+}
+
+func TestRecordFailedHealthCheckMetrics(t *testing.T) {
+	issuerName := types.NamespacedName{Namespace: "ns1", Name: "record-failed-health-check"}
+	issuerSpec := &cfsslissuerapi.IssuerSpec{
+		Label:          "signer1-label",
+		Profile:        "signer1-profile",
+		FallbackLabels: []string{"signer2-label"},
+		Rollout:        &cfsslissuerapi.LabelRollout{Label: "signer3-label", Weight: 10},
+	}
+	t.Cleanup(func() { deleteIssuerMetrics("Issuer", issuerName) })
+	notAfter := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	recordHealthCheckMetrics("Issuer", issuerName, issuerSpec, &signer.HealthCheckResult{
+		Endpoints: []cfsslissuerapi.EndpointStatus{{URL: "https://cfssl1.example.com", Healthy: true}},
+		Labels:    []cfsslissuerapi.LabelStatus{{Label: "signer1-label", Healthy: true}},
+		Signers:   []signer.SignerStatus{{Label: "signer1-label", Endpoint: "https://cfssl1.example.com", NotAfter: notAfter}},
+	}, cfsslissuerapi.IssuerReasonHealthy)
+
+	// Every label is reported as unhealthy, the CA seen last is kept
+	recordFailedHealthCheckMetrics("Issuer", issuerName, issuerSpec, cfsslissuerapi.IssuerReasonAuthSecretUnavailable)
+	assert.Equal(t, 3, seriesCount(t, healthCheckStatus, issuerName.Name))
+	for _, label := range []string{"signer1-label", "signer2-label", "signer3-label"} {
+		assert.Equal(t, 0.0, metricValue(t, healthCheckStatus.WithLabelValues("Issuer", "ns1", "record-failed-health-check", label, "signer1-profile", "", "AuthSecretUnavailable")))
+	}
+	assert.Equal(t, 1, seriesCount(t, caNotAfter, issuerName.Name))
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2021 The Wikimedia Foundation, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"errors"
+	"fmt"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+
+	cfsslissuerapi "gerrit.wikimedia.org/r/operations/software/cfssl-issuer/api/v1alpha1"
+)
+
+var (
+	errProfileNotAllowed = errors.New("profile is not allowed by the issuer")
+	errNoProfileMatches  = errors.New("no profile of the issuer matches the request")
+)
+
+// requestedProfile returns the CFSSL profile to sign a CertificateRequest
+// with, or "" if the default profile of the {Cluster}Issuer should be used.
+// A profile explicitly requested via the ProfileAnnotationKey annotation takes
+// precedence over the ProfileSelectors of the {Cluster}Issuer.
+func requestedProfile(certificateRequest *cmapi.CertificateRequest, issuerSpec *cfsslissuerapi.IssuerSpec) (string, error) {
+	if profile := certificateRequest.Annotations[cfsslissuerapi.ProfileAnnotationKey]; profile != "" {
+		if profile == issuerSpec.Profile {
+			return "", nil
+		}
+		for _, allowed := range issuerSpec.AllowedProfiles {
+			if profile == allowed {
+				return profile, nil
+			}
+		}
+		return "", fmt.Errorf("%w: %q", errProfileNotAllowed, profile)
+	}
+
+	if len(issuerSpec.ProfileSelectors) == 0 {
+		return "", nil
+	}
+	usages := certificateRequest.Spec.Usages
+	if len(usages) == 0 {
+		usages = cmapi.DefaultKeyUsages()
+	}
+	for _, selector := range issuerSpec.ProfileSelectors {
+		if profileSelectorMatches(selector, certificateRequest.Spec.IsCA, usages) {
+			return selector.Profile, nil
+		}
+	}
+	return "", fmt.Errorf("%w: isCA: %t, usages: %v", errNoProfileMatches, certificateRequest.Spec.IsCA, usages)
+}
+
+// profileSelectorMatches reports whether a profile selected by selector
+// satisfies a request for isCA and usages.
+func profileSelectorMatches(selector cfsslissuerapi.ProfileSelector, isCA bool, usages []cmapi.KeyUsage) bool {
+	if selector.IsCA != nil && *selector.IsCA != isCA {
+		return false
+	}
+	if len(selector.Usages) == 0 {
+		return true
+	}
+	for _, usage := range usages {
+		found := false
+		for _, provided := range selector.Usages {
+			if string(usage) == provided {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
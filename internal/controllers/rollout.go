@@ -0,0 +1,112 @@
+/*
+Copyright 2021 The Wikimedia Foundation, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"hash/fnv"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cfsslissuerapi "gerrit.wikimedia.org/r/operations/software/cfssl-issuer/api/v1alpha1"
+	issuerutil "gerrit.wikimedia.org/r/operations/software/cfssl-issuer/internal/issuer/util"
+)
+
+// Number of attempts to update the rollout counters of an Issuer in case of
+// conflicting updates.
+const rolloutStatusAttempts = 5
+
+// rolloutLabel returns the label a CertificateRequest is signed with first,
+// according to the rollout of the issuer.
+func rolloutLabel(certificateRequest *cmapi.CertificateRequest, issuerSpec *cfsslissuerapi.IssuerSpec) string {
+	rollout := issuerSpec.Rollout
+	if rollout == nil || rollout.Label == "" {
+		return issuerSpec.Label
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(rolloutKey(certificateRequest)))
+	if int32(h.Sum32()%100) < rollout.Weight {
+		return rollout.Label
+	}
+	return issuerSpec.Label
+}
+
+// rolloutKey identifies the Certificate a CertificateRequest was created for,
+// so that all of its CertificateRequests get the same label. CertificateRequests
+// not owned by a Certificate are identified by themselves.
+func rolloutKey(certificateRequest *cmapi.CertificateRequest) string {
+	if owner := metav1.GetControllerOf(certificateRequest); owner != nil {
+		return certificateRequest.Namespace + "/" + owner.Kind + "/" + owner.Name
+	}
+	return certificateRequest.Namespace + "/" + certificateRequest.Name
+}
+
+// resetRolloutStatus clears the rollout counters if the rollout has been
+// removed from the spec or changed since they were started.
+func resetRolloutStatus(issuerSpec *cfsslissuerapi.IssuerSpec, issuerStatus *cfsslissuerapi.IssuerStatus) {
+	rollout := issuerSpec.Rollout
+	if rollout == nil {
+		issuerStatus.Rollout = nil
+		return
+	}
+	if issuerStatus.Rollout == nil || issuerStatus.Rollout.Label != rollout.Label || issuerStatus.Rollout.Weight != rollout.Weight {
+		issuerStatus.Rollout = &cfsslissuerapi.RolloutStatus{
+			Label:  rollout.Label,
+			Weight: rollout.Weight,
+		}
+	}
+}
+
+// countRolloutRequest increments the rollout counter of the issuer for the
+// label a CertificateRequest was issued with. It is a no-op if the issuer has
+// no rollout. Only the rollout status is patched, so that updates of the rest
+// of the status by the issuer controllers are neither overwritten nor
+// conflicting.
+func countRolloutRequest(ctx context.Context, c client.Client, issuer client.Object, label string) error {
+	var err error
+	for i := 0; i < rolloutStatusAttempts; i++ {
+		if i > 0 {
+			// Start over with the current version after a conflict
+			if err = c.Get(ctx, client.ObjectKeyFromObject(issuer), issuer); err != nil {
+				return err
+			}
+		}
+		issuerSpec, issuerStatus, specErr := issuerutil.GetSpecAndStatus(issuer)
+		if specErr != nil {
+			return specErr
+		}
+		if issuerSpec.Rollout == nil {
+			return nil
+		}
+		// The resource version is part of the patch, so counts based on a
+		// stale issuer are rejected
+		patch := client.MergeFromWithOptions(issuer.DeepCopyObject().(client.Object), client.MergeFromWithOptimisticLock{})
+		resetRolloutStatus(issuerSpec, issuerStatus)
+		if label == issuerSpec.Rollout.Label {
+			issuerStatus.Rollout.SecondaryRequests++
+		} else {
+			issuerStatus.Rollout.PrimaryRequests++
+		}
+		if err = c.Status().Patch(ctx, issuer, patch); !apierrors.IsConflict(err) {
+			return err
+		}
+	}
+	return err
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2021 The Wikimedia Foundation, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	cmutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cfsslissuerapi "gerrit.wikimedia.org/r/operations/software/cfssl-issuer/api/v1alpha1"
+)
+
+var errReissueCertificates = errors.New("failed to re-issue Certificates after CA rotation")
+
+const (
+	// Maximum number of Certificates re-issued per reconcile after a CA
+	// rotation, so that cert-manager and the CFSSL API are not flooded.
+	reissueBatchSize = 10
+
+	// Time before the next batch of Certificates is re-issued.
+	reissueBatchInterval = 30 * time.Second
+)
+
+// reissueCertificates triggers the re-issuance of up to limit cert-manager
+// Certificates referencing the {Cluster}Issuer of the given kind, the same way
+// "cmctl renew" does. Re-issued Certificates are annotated with the
+// fingerprint of the new signer certificate, Certificates already annotated
+// with it are skipped.
+// It returns the number of Certificates re-issued and of those left.
+func reissueCertificates(ctx context.Context, c client.Client, issuer client.Object, kind, fingerprint string, limit int) (int, int, error) {
+	var opts []client.ListOption
+	if kind == "Issuer" {
+		opts = append(opts, client.InNamespace(issuer.GetNamespace()))
+	}
+	var certificates cmapi.CertificateList
+	if err := c.List(ctx, &certificates, opts...); err != nil {
+		return 0, 0, err
+	}
+
+	var pending []*cmapi.Certificate
+	for i := range certificates.Items {
+		crt := &certificates.Items[i]
+		ref := crt.Spec.IssuerRef
+		if ref.Group != cfsslissuerapi.GroupVersion.Group || ref.Kind != kind || ref.Name != issuer.GetName() {
+			continue
+		}
+		if crt.Annotations[cfsslissuerapi.CARotatedAnnotationKey] == fingerprint {
+			continue
+		}
+		pending = append(pending, crt)
+	}
+
+	reissued := 0
+	for _, crt := range pending {
+		if reissued == limit {
+			break
+		}
+
+		// The annotation is set last, so a Certificate is triggered again if
+		// setting it fails.
+		statusPatch := client.MergeFrom(crt.DeepCopy())
+		cmutil.SetCertificateCondition(crt, crt.Generation, cmapi.CertificateConditionIssuing, cmmeta.ConditionTrue,
+			cfsslissuerapi.EventReasonCARotated, "Re-issuing as the signer certificate of the issuer changed")
+		if err := c.Status().Patch(ctx, crt, statusPatch); err != nil {
+			return reissued, len(pending) - reissued, err
+		}
+		patch := client.MergeFrom(crt.DeepCopy())
+		metav1.SetMetaDataAnnotation(&crt.ObjectMeta, cfsslissuerapi.CARotatedAnnotationKey, fingerprint)
+		if err := c.Patch(ctx, crt, patch); err != nil {
+			return reissued, len(pending) - reissued, err
+		}
+		reissued++
+	}
+	return reissued, len(pending) - reissued, nil
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2021 The Wikimedia Foundation, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"time"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+const (
+	// Clock skew tolerated between the CFSSL API and the controller when
+	// checking the validity period of issued certificates.
+	clockSkewTolerance = time.Minute
+)
+
+var (
+	errCertificateVerification = errors.New("issued certificate failed verification")
+)
+
+// verifyCertificate checks that the PEM encoded certificate (chain) returned
+// by the CFSSL API actually satisfies the CertificateRequest: the public key
+// and SANs match the CSR, the requested usages are present, it chains up to
+// the PEM encoded CA (if there is one) and it is currently valid.
+func verifyCertificate(certificateRequest *cmapi.CertificateRequest, csr *x509.CertificateRequest, certPEM, caPEM []byte, now time.Time) error {
+	chain, err := pki.DecodeX509CertificateChainBytes(certPEM)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errCertificateVerification, err)
+	}
+	leaf := chain[0]
+
+	if equal, err := pki.PublicKeysEqual(leaf.PublicKey, csr.PublicKey); err != nil || !equal {
+		return fmt.Errorf("%w: public key does not match the CSR", errCertificateVerification)
+	}
+	if err := verifySANs(leaf, csr); err != nil {
+		return fmt.Errorf("%w: %v", errCertificateVerification, err)
+	}
+	if err := verifyUsages(leaf, certificateRequest.Spec.Usages, certificateRequest.Spec.IsCA); err != nil {
+		return fmt.Errorf("%w: %v", errCertificateVerification, err)
+	}
+
+	if now.Add(clockSkewTolerance).Before(leaf.NotBefore) || now.After(leaf.NotAfter) {
+		return fmt.Errorf("%w: certificate is only valid from %s to %s", errCertificateVerification, leaf.NotBefore, leaf.NotAfter)
+	}
+	// Verify the chain at a time the certificate is valid, as the validity
+	// period has been checked (with tolerance) already.
+	if now.Before(leaf.NotBefore) {
+		now = leaf.NotBefore
+	}
+
+	if len(caPEM) == 0 {
+		return nil
+	}
+	caCerts, err := pki.DecodeX509CertificateChainBytes(caPEM)
+	if err != nil {
+		return fmt.Errorf("%w: invalid CA: %v", errCertificateVerification, err)
+	}
+	roots := x509.NewCertPool()
+	for _, cert := range caCerts {
+		roots.AddCert(cert)
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range chain[1:] {
+		intermediates.AddCert(cert)
+	}
+	if _, err := leaf.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		CurrentTime:   now,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}); err != nil {
+		return fmt.Errorf("%w: %v", errCertificateVerification, err)
+	}
+	return nil
+}
+
+// verifySANs checks that the SANs of cert are exactly the ones requested by csr.
+func verifySANs(cert *x509.Certificate, csr *x509.CertificateRequest) error {
+	if !equalStrings(cert.DNSNames, csr.DNSNames) {
+		return fmt.Errorf("DNS names %v do not match the requested %v", cert.DNSNames, csr.DNSNames)
+	}
+	if !equalStrings(ipStrings(cert.IPAddresses), ipStrings(csr.IPAddresses)) {
+		return fmt.Errorf("IP addresses %v do not match the requested %v", cert.IPAddresses, csr.IPAddresses)
+	}
+	if !equalStrings(urlStrings(cert.URIs), urlStrings(csr.URIs)) {
+		return fmt.Errorf("URIs %v do not match the requested %v", cert.URIs, csr.URIs)
+	}
+	if !equalStrings(cert.EmailAddresses, csr.EmailAddresses) {
+		return fmt.Errorf("email addresses %v do not match the requested %v", cert.EmailAddresses, csr.EmailAddresses)
+	}
+	return nil
+}
+
+// verifyUsages checks that cert is a CA certificate if and only if isCA is
+// set, and that it provides all of the explicitly requested usages.
+func verifyUsages(cert *x509.Certificate, usages []cmapi.KeyUsage, isCA bool) error {
+	if cert.IsCA != isCA {
+		return fmt.Errorf("certificate has isCA %t, but %t was requested", cert.IsCA, isCA)
+	}
+	// Without explicitly requested usages, the profile decides
+	if len(usages) == 0 {
+		return nil
+	}
+	ku, ekus, err := pki.KeyUsagesForCertificateOrCertificateRequest(usages, isCA)
+	if err != nil {
+		return err
+	}
+	if cert.KeyUsage&ku != ku {
+		return fmt.Errorf("key usages %v do not include the requested %v", pki.BuildCertManagerKeyUsages(cert.KeyUsage, nil), pki.BuildCertManagerKeyUsages(ku, nil))
+	}
+	for _, eku := range ekus {
+		if !hasExtKeyUsage(cert, eku) {
+			return fmt.Errorf("extended key usages %v do not include the requested %v", pki.BuildCertManagerKeyUsages(0, cert.ExtKeyUsage), pki.BuildCertManagerKeyUsages(0, ekus))
+		}
+	}
+	return nil
+}
+
+func hasExtKeyUsage(cert *x509.Certificate, eku x509.ExtKeyUsage) bool {
+	for _, provided := range cert.ExtKeyUsage {
+		if provided == eku || provided == x509.ExtKeyUsageAny {
+			return true
+		}
+	}
+	return false
+}
+
+// equalStrings reports whether a and b contain the same strings, regardless of order.
+func equalStrings(a, b []string) bool {
+	counts := map[string]int{}
+	for _, s := range a {
+		counts[s]++
+	}
+	for _, s := range b {
+		counts[s]--
+	}
+	for _, c := range counts {
+		if c != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func ipStrings(ips []net.IP) []string {
+	s := make([]string, 0, len(ips))
+	for _, ip := range ips {
+		s = append(s, ip.String())
+	}
+	return s
+}
+
+func urlStrings(urls []*url.URL) []string {
+	s := make([]string, 0, len(urls))
+	for _, u := range urls {
+		s = append(s, u.String())
+	}
+	return s
+}
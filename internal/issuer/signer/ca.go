@@ -0,0 +1,87 @@
+package signer
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"errors"
+	"fmt"
+
+	cfsslissuerapi "gerrit.wikimedia.org/r/operations/software/cfssl-issuer/api/v1alpha1"
+	cfsslinfo "github.com/cloudflare/cfssl/info"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var (
+	errInvalidTrustAnchors = errors.New("trust anchors do not contain any valid PEM encoded certificate")
+	errSignerCertificate   = errors.New("failed to get the signer certificate")
+	errUntrustedSigner     = errors.New("signer certificate does not chain up to a trust anchor")
+)
+
+// newTrustAnchors returns the pool of trust anchors for the TrustAnchor CA source.
+func newTrustAnchors(caConfig *cfsslissuerapi.CAConfig) (*x509.CertPool, error) {
+	if caConfig == nil || caConfig.Source != cfsslissuerapi.CASourceTrustAnchor {
+		return nil, nil
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caConfig.TrustAnchors) {
+		return nil, errInvalidTrustAnchors
+	}
+	return pool, nil
+}
+
+// signerCertificate fetches the certificate of the signer of label from the info endpoint.
+func (c *cfssl) signerCertificate(ctx context.Context, label string) (*x509.Certificate, error) {
+	jsonData, err := c.infoRequest(label)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.client.Info(ctx, jsonData)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errSignerCertificate, err)
+	}
+	return parseSignerCertificate(resp)
+}
+
+// parseSignerCertificate parses the signer certificate of an info response.
+func parseSignerCertificate(resp *cfsslinfo.Resp) (*x509.Certificate, error) {
+	cert, err := parseCertificate([]byte(resp.Certificate))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errSignerCertificate, err)
+	}
+	return cert, nil
+}
+
+// caStatus returns the details of the signer certificate and profile reported
+// in the Issuer status.
+func caStatus(signerCert *x509.Certificate, resp *cfsslinfo.Resp) *cfsslissuerapi.CAStatus {
+	return &cfsslissuerapi.CAStatus{
+		Subject:           signerCert.Subject.String(),
+		SerialNumber:      signerCert.SerialNumber.String(),
+		SHA256Fingerprint: fmt.Sprintf("%x", sha256.Sum256(signerCert.Raw)),
+		NotBefore:         metav1.NewTime(signerCert.NotBefore),
+		NotAfter:          metav1.NewTime(signerCert.NotAfter),
+		Usages:            resp.Usage,
+		Expiry:            resp.ExpiryString,
+	}
+}
+
+// ca returns the PEM encoded CA to provide along with certificates issued by
+// signerCert, according to the configured CA source.
+func (c *cfssl) ca(signerCert *x509.Certificate) ([]byte, error) {
+	switch c.caSource {
+	case cfsslissuerapi.CASourceSigner:
+		return encodeCertificates([]*x509.Certificate{signerCert}), nil
+	case cfsslissuerapi.CASourceTrustAnchor:
+		chains, err := signerCert.Verify(x509.VerifyOptions{
+			Roots:     c.trustAnchors,
+			KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", errUntrustedSigner, err)
+		}
+		return encodeCertificates(chains[0]), nil
+	default:
+		return nil, fmt.Errorf("unsupported CA source: %q", c.caSource)
+	}
+}
@@ -2,34 +2,137 @@ package signer
 
 import (
 	"context"
-	"crypto/tls"
 	"crypto/x509"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
+	"time"
 
 	cfsslissuerapi "gerrit.wikimedia.org/r/operations/software/cfssl-issuer/api/v1alpha1"
-	cfsslclient "github.com/cloudflare/cfssl/api/client"
 	cfsslauth "github.com/cloudflare/cfssl/auth"
 	cfsslinfo "github.com/cloudflare/cfssl/info"
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
+const (
+	// Used if the IssuerSpec does not specify a timeout.
+	defaultRequestTimeout = 30 * time.Second
+)
+
 var (
-	errCfsslAuthProvider = errors.New("failed creating cfssl auth provider")
+	errNoLabels     = errors.New("no signer label configured")
+	errUnknownLabel = errors.New("label is not configured for the issuer")
+
+	// ErrInvalidAuthKey is returned by the builders if a key in the auth
+	// Secret can not be used to create a cfssl auth provider.
+	ErrInvalidAuthKey = errors.New("failed creating cfssl auth provider")
+
+	// ErrAuthenticationFailed is returned by authenticated health checks if the
+	// CFSSL API is reachable but rejects the authenticated request.
+	ErrAuthenticationFailed = errors.New("authenticated request to the CFSSL API failed")
 )
 
 type HealthChecker interface {
-	Check() error
+	Check(context.Context) (*HealthCheckResult, error)
 }
 
-type HealthCheckerBuilder func(issuerSpec *cfsslissuerapi.IssuerSpec, secretData map[string][]byte) (HealthChecker, error)
+// HealthCheckResult holds details observed during a health check. It is
+// returned along with errors as far as it could be populated.
+type HealthCheckResult struct {
+	// Health of every CFSSL API endpoint of the Issuer.
+	Endpoints []cfsslissuerapi.EndpointStatus
+
+	// Signer certificate and profile returned by the info endpoint for the
+	// primary label, nil if it is not healthy. Those of the other labels are
+	// in Labels.
+	CA *cfsslissuerapi.CAStatus
+
+	// Health of every label of the Issuer.
+	Labels []cfsslissuerapi.LabelStatus
+
+	// Field of the auth Secret containing the key last accepted by the CFSSL
+	// API, by a signing request or the authenticated health check. Empty if no
+	// authenticated request succeeded since the controller started, or if the
+	// keys were rejected since.
+	AcceptedKey string
+
+	// Whether the authenticated health check succeeded.
+	AuthVerified bool
+
+	// Signer certificates returned by the info endpoint, for every label
+	// which returned one.
+	Signers []SignerStatus
+}
+
+// SignerStatus holds details of the signer certificate of a label.
+type SignerStatus struct {
+	Label string
+
+	// URL of the CFSSL API endpoint which returned the certificate.
+	Endpoint string
+
+	// End of the validity of the signer certificate.
+	NotAfter time.Time
+}
+
+type HealthCheckerBuilder func(issuerSpec *cfsslissuerapi.IssuerSpec, issuerData *IssuerData) (HealthChecker, error)
 
 type Signer interface {
-	Sign(context.Context, []byte) ([]byte, []byte, error)
+	Sign(context.Context, *SignRequest) (*SignResult, error)
+}
+
+// SignRequest holds the parameters of a single signing request.
+type SignRequest struct {
+	// PEM encoded CSR.
+	CSR []byte
+
+	// Requested validity of the certificate. If zero, the expiry of the CFSSL
+	// profile is used.
+	Duration time.Duration
+
+	// CFSSL profile to sign with. If empty, the profile of the IssuerSpec is used.
+	Profile string
+
+	// Label of the CFSSL signer to try first, either one of the labels of the
+	// IssuerSpec or the one of its rollout. If empty, the Label of the
+	// IssuerSpec is used.
+	Label string
+}
+
+// SignResult holds the outcome of a signing request. If signing failed, only
+// Label and Endpoint are set, as far as the request got.
+type SignResult struct {
+	// PEM encoded CA to provide along with the certificate, may be empty.
+	CA []byte
+
+	// PEM encoded certificate (chain).
+	Certificate []byte
+
+	// Label of the CFSSL signer which signed the certificate.
+	Label string
+
+	// URL of the CFSSL API endpoint which signed the certificate.
+	Endpoint string
 }
 
-type SignerBuilder func(issuerSpec *cfsslissuerapi.IssuerSpec, secretData map[string][]byte) (Signer, error)
+type SignerBuilder func(issuerSpec *cfsslissuerapi.IssuerSpec, issuerData *IssuerData) (Signer, error)
+
+// IssuerData holds the contents of the Kubernetes resources referenced by an IssuerSpec.
+type IssuerData struct {
+	// Identifies the Issuer or ClusterIssuer, the state of its CFSSL API
+	// endpoints is kept under this key.
+	IssuerKey string
+
+	// Data of the Secret referenced by AuthSecretName.
+	AuthSecretData map[string][]byte
+
+	// PEM encoded CA bundle from CABundle and CABundleRef.
+	CABundle []byte
+
+	// Data of the "kubernetes.io/tls" Secret referenced by ClientCertificateSecretName.
+	ClientCertificateSecretData map[string][]byte
+}
 
 // Request body send to CFSSL authsign endpoint.
 // While the API defines "label" as optional, we have it mandatory here as
@@ -40,6 +143,9 @@ type cfsslapiCertificateRequest struct {
 	Label   string `json:"label"`
 	Profile string `json:"profile,omitempty"`
 	Bundle  bool   `json:"bundle,omitempty"`
+	// Override the expiry of the profile if set
+	NotBefore *time.Time `json:"not_before,omitempty"`
+	NotAfter  *time.Time `json:"not_after,omitempty"`
 }
 
 // Request body send to CFSSL info endpoint.
@@ -51,92 +157,353 @@ type cfsslapiInfoRequest struct {
 
 // BasicRemote is a stripped down version of cfssl.Remote to make mocking easier
 type BasicRemote interface {
-	Sign(jsonData []byte) ([]byte, error)
-	BundleSign(jsonData []byte) ([]byte, []byte, error)
-	Info(jsonData []byte) (*cfsslinfo.Resp, error)
+	Sign(ctx context.Context, jsonData []byte) ([]byte, error)
+	BundleSign(ctx context.Context, jsonData []byte) ([]byte, []byte, error)
+	Info(ctx context.Context, jsonData []byte) (*cfsslinfo.Resp, error)
+	AuthInfo(ctx context.Context, jsonData []byte) ([]byte, error)
+	CheckEndpoints(ctx context.Context, jsonData []byte) (*cfsslinfo.Resp, []cfsslissuerapi.EndpointStatus, error)
+	AcceptedKey() string
+	Endpoint() string
 }
 
 type cfssl struct {
-	client  BasicRemote
-	label   string
-	profile string
-	bundle  bool
+	client BasicRemote
+	// Labels of the signers, tried in order
+	labels []string
+	// Secondary label of a rollout, empty for none
+	rolloutLabel string
+	profile      string
+	bundle       bool
+
+	authenticatedHealthCheck bool
+
+	// Where to take the CA from if bundle is false, empty for none.
+	caSource     cfsslissuerapi.CASource
+	trustAnchors *x509.CertPool
+
+	// Pins the signer certificate has to match, nil for none.
+	pins *caPins
+}
+
+// requestTimeout returns the timeout of requests to the CFSSL API. A timeout of
+// zero would disable it, so it is replaced by the default like a negative one.
+func requestTimeout(issuerSpec *cfsslissuerapi.IssuerSpec) time.Duration {
+	if issuerSpec.Timeout != nil && issuerSpec.Timeout.Duration > 0 {
+		return issuerSpec.Timeout.Duration
+	}
+	return defaultRequestTimeout
 }
 
-func newCfssl(issuerSpec *cfsslissuerapi.IssuerSpec, secretData map[string][]byte) (*cfssl, error) {
-	rootCAs, _ := x509.SystemCertPool()
-	tlsconfig := &tls.Config{
-		RootCAs: rootCAs,
+// newCircuitBreaker returns the circuit breaker configured by the IssuerSpec,
+// using the defaults for omitted or non-positive values.
+func newCircuitBreaker(issuerSpec *cfsslissuerapi.IssuerSpec) circuitBreaker {
+	breaker := circuitBreaker{
+		failureThreshold: defaultCircuitBreakerFailureThreshold,
+		coolDown:         defaultCircuitBreakerCoolDown,
+	}
+	if cb := issuerSpec.CircuitBreaker; cb != nil {
+		if cb.FailureThreshold > 0 {
+			breaker.failureThreshold = int(cb.FailureThreshold)
+		}
+		if cb.CoolDown != nil && cb.CoolDown.Duration > 0 {
+			breaker.coolDown = cb.CoolDown.Duration
+		}
 	}
-	keyStr := string(secretData["key"])
-	authProvider, err := cfsslauth.New(keyStr, secretData["additional_data"])
+	return breaker
+}
+
+func newCfssl(issuerSpec *cfsslissuerapi.IssuerSpec, issuerData *IssuerData) (*cfssl, error) {
+	tlsconfig, err := newTLSConfig(issuerData.CABundle, issuerData.ClientCertificateSecretData)
+	if err != nil {
+		return nil, err
+	}
+	authKeys, err := newAuthKeys(issuerData.AuthSecretData)
 	if err != nil {
-		return nil, fmt.Errorf("%w reason: %s", errCfsslAuthProvider, err)
+		return nil, err
 	}
 
 	//FIXME: Because of a bug in cfssl normalizeURL function, issuerSpec.URL must not end in a /
-	return &cfssl{
-		client:  cfsslclient.NewAuthServer(issuerSpec.URL, tlsconfig, authProvider),
-		label:   issuerSpec.Label,
+	strategy := issuerSpec.Strategy
+	if strategy == "" {
+		strategy = cfsslissuerapi.URLStrategyOrderedList
+	}
+	client, err := newAuthRemote(issuerData.IssuerKey, issuerSpec.URL, tlsconfig, authKeys, requestTimeout(issuerSpec), strategy, newCircuitBreaker(issuerSpec))
+	if err != nil {
+		return nil, err
+	}
+	trustAnchors, err := newTrustAnchors(issuerSpec.CA)
+	if err != nil {
+		return nil, err
+	}
+	pins, err := newCAPins(issuerSpec.CAPins)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &cfssl{
+		client:  client,
+		labels:  append([]string{issuerSpec.Label}, issuerSpec.FallbackLabels...),
 		profile: issuerSpec.Profile,
 		bundle:  issuerSpec.Bundle,
-	}, nil
+		pins:    pins,
+	}
+	if issuerSpec.Rollout != nil {
+		c.rolloutLabel = issuerSpec.Rollout.Label
+	}
+	if issuerSpec.HealthCheck != nil {
+		c.authenticatedHealthCheck = issuerSpec.HealthCheck.Authenticated
+	}
+	if issuerSpec.CA != nil {
+		c.caSource = issuerSpec.CA.Source
+		c.trustAnchors = trustAnchors
+	}
+	return c, nil
 }
 
-func NewCfsslSigner(issuerSpec *cfsslissuerapi.IssuerSpec, secretData map[string][]byte) (Signer, error) {
-	return newCfssl(issuerSpec, secretData)
+// newAuthKeys returns the keys from the auth Secret in the order they are
+// tried. "key.next" is optional, it allows for rotating the key without
+// downtime.
+func newAuthKeys(data map[string][]byte) ([]authKey, error) {
+	var keys []authKey
+	for _, name := range []string{"key", "key.next"} {
+		key, ok := data[name]
+		if !ok && name != "key" {
+			continue
+		}
+		provider, err := cfsslauth.New(string(key), data["additional_data"])
+		if err != nil {
+			return nil, fmt.Errorf("%w reason: %s", ErrInvalidAuthKey, err)
+		}
+		keys = append(keys, authKey{name: name, provider: provider})
+	}
+	return keys, nil
 }
 
-func NewCfsslHealthChecker(issuerSpec *cfsslissuerapi.IssuerSpec, secretData map[string][]byte) (HealthChecker, error) {
-	return newCfssl(issuerSpec, secretData)
+func NewCfsslSigner(issuerSpec *cfsslissuerapi.IssuerSpec, issuerData *IssuerData) (Signer, error) {
+	return newCfssl(issuerSpec, issuerData)
 }
 
-// Check is called for health checks
-func (c *cfssl) Check() error {
-	// Unfortunately the /api/v1/cfssl/info endpoint is only available without authentication,
-	// so this won't check credentials early.
+func NewCfsslHealthChecker(issuerSpec *cfsslissuerapi.IssuerSpec, issuerData *IssuerData) (HealthChecker, error) {
+	return newCfssl(issuerSpec, issuerData)
+}
+
+func (c *cfssl) infoRequest(label string) ([]byte, error) {
 	infoReq := cfsslapiInfoRequest{
-		Label:   c.label,
+		Label:   label,
 		Profile: c.profile,
 	}
 	jsonData, err := json.Marshal(infoReq)
 	if err != nil {
-		return fmt.Errorf("Failed to json.Marshal CSR: %w", err)
+		return nil, fmt.Errorf("Failed to json.Marshal info request: %w", err)
 	}
-	_, err = c.client.Info(jsonData)
-	return err
+	return jsonData, nil
 }
 
-func (c *cfssl) Sign(ctx context.Context, csrBytes []byte) ([]byte, []byte, error) {
+// Check is called for health checks. Every label is checked, the CFSSL API is
+// considered healthy as long as one of them is. A signer certificate not
+// matching the CA pins fails the check whatever the other labels report.
+func (c *cfssl) Check(ctx context.Context) (*HealthCheckResult, error) {
+	result := &HealthCheckResult{}
+	var healthyLabel string
+	var firstErr, pinErr error
+	labels := c.labels
+	if c.rolloutLabel != "" && !slices.Contains(labels, c.rolloutLabel) {
+		labels = append(labels[:len(labels):len(labels)], c.rolloutLabel)
+	}
+	for i, label := range labels {
+		ca, err := c.checkLabel(ctx, label, i == 0, result)
+		status := cfsslissuerapi.LabelStatus{Label: label, Healthy: err == nil}
+		// Only the CA of the primary label is reported as the one of the
+		// issuer, so that a failing primary label is not taken for a rotation.
+		if i == 0 {
+			result.CA = ca
+		} else {
+			status.CA = ca
+		}
+		if err != nil {
+			status.LastError = err.Error()
+			if firstErr == nil {
+				firstErr = err
+			}
+			if pinErr == nil && errors.Is(err, ErrCAPinMismatch) {
+				pinErr = err
+			}
+		} else if healthyLabel == "" {
+			healthyLabel = label
+		}
+		result.Labels = append(result.Labels, status)
+	}
+	if pinErr != nil {
+		return result, pinErr
+	}
+	if healthyLabel == "" {
+		return result, classifyCheck(firstErr)
+	}
+
+	// The /api/v1/cfssl/info endpoint does not require authentication, so a wrong
+	// key would only be noticed when signing. If enabled, additionally query the
+	// authenticated variant. As the API was just reachable, a failure here is most
+	// likely caused by the credentials.
+	if !c.authenticatedHealthCheck {
+		result.AcceptedKey = c.client.AcceptedKey()
+		return result, nil
+	}
+	jsonData, err := c.infoRequest(healthyLabel)
+	if err != nil {
+		return result, err
+	}
+	_, err = c.client.AuthInfo(ctx, jsonData)
+	result.AcceptedKey = c.client.AcceptedKey()
+	if err != nil {
+		return result, fmt.Errorf("%w: %v", ErrAuthenticationFailed, err)
+	}
+	result.AuthVerified = true
+	return result, nil
+}
+
+// checkLabel checks the signer of a single label and returns its CA status if
+// it is healthy. The health of the endpoints does not depend on the label, it
+// is only observed for the first one.
+func (c *cfssl) checkLabel(ctx context.Context, label string, first bool, result *HealthCheckResult) (*cfsslissuerapi.CAStatus, error) {
+	jsonData, err := c.infoRequest(label)
+	if err != nil {
+		return nil, err
+	}
+	var resp *cfsslinfo.Resp
+	if first {
+		resp, result.Endpoints, err = c.client.CheckEndpoints(ctx, jsonData)
+	} else {
+		resp, err = c.client.Info(ctx, jsonData)
+	}
+	if err != nil {
+		return nil, err
+	}
+	signerCert, err := parseSignerCertificate(resp)
+	if err != nil {
+		return nil, err
+	}
+	result.Signers = append(result.Signers, SignerStatus{
+		Label:    label,
+		Endpoint: c.client.Endpoint(),
+		NotAfter: signerCert.NotAfter,
+	})
+
+	// A wrong label or CFSSL configuration could make the API sign with an
+	// unexpected certificate.
+	if c.pins != nil {
+		if err := c.pins.verify(signerCert); err != nil {
+			return nil, err
+		}
+	}
+	return caStatus(signerCert, resp), nil
+}
+
+// Sign signs the CSR with the signer of the requested label, or the first one
+// if none was requested. If that fails because of the signer, the next label is
+// tried. A failure after a label was tried is returned along with the label and
+// the endpoint which answered.
+func (c *cfssl) Sign(ctx context.Context, req *SignRequest) (*SignResult, error) {
 	log := ctrl.LoggerFrom(ctx)
 
 	// Verify valid CSR
-	_, err := parseCSR(csrBytes)
+	_, err := ParseCSR(req.CSR)
+	if err != nil {
+		return nil, err
+	}
+
+	labels, err := c.signLabels(req.Label)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
+	}
+	for i, label := range labels {
+		result, err := c.signWithLabel(ctx, req, label)
+		if err == nil {
+			return result, nil
+		}
+		if i == len(labels)-1 || !isSignerFailure(err) {
+			return &SignResult{Label: label, Endpoint: c.client.Endpoint()}, err
+		}
+		log.Info("Signing failed, falling back to the next label", "label", label, "error", err.Error())
+	}
+	return nil, errNoLabels
+}
+
+// signLabels returns the labels to try in order, starting with first.
+func (c *cfssl) signLabels(first string) ([]string, error) {
+	if first == "" {
+		return c.labels, nil
 	}
+	if first != c.rolloutLabel && !slices.Contains(c.labels, first) {
+		return nil, fmt.Errorf("%w: %q", errUnknownLabel, first)
+	}
+	labels := []string{first}
+	for _, label := range c.labels {
+		if label != first {
+			labels = append(labels, label)
+		}
+	}
+	return labels, nil
+}
 
+func (c *cfssl) signWithLabel(ctx context.Context, req *SignRequest, label string) (*SignResult, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	profile := c.profile
+	if req.Profile != "" {
+		profile = req.Profile
+	}
 	csr := cfsslapiCertificateRequest{
-		CSR:     string(csrBytes),
-		Label:   c.label,
-		Profile: c.profile,
+		CSR:     string(req.CSR),
+		Label:   label,
+		Profile: profile,
 		Bundle:  c.bundle,
 	}
-	log.Info("Signing cert with", "label", c.label, "profile", c.profile, "bundle", c.bundle)
+	// Only not_after is sent, so that CFSSL keeps backdating not_before for
+	// clients with clocks running slightly behind.
+	if req.Duration > 0 {
+		notAfter := now().UTC().Truncate(time.Second).Add(req.Duration)
+		csr.NotAfter = &notAfter
+	}
+	log.Info("Signing cert with", "label", label, "profile", profile, "bundle", c.bundle, "duration", req.Duration)
 	jsonData, err := json.Marshal(csr)
 	if err != nil {
-		return nil, nil, fmt.Errorf("Failed to json.Marshal CSR: %w", err)
+		return nil, fmt.Errorf("Failed to json.Marshal CSR: %w", err)
+	}
+
+	// The signer certificate is fetched and verified before signing so that no
+	// certificate is issued in vain if the CA cannot be provided or the signer
+	// is not the pinned one.
+	var signerCert *x509.Certificate
+	if c.pins != nil || (!c.bundle && c.caSource != "") {
+		if signerCert, err = c.signerCertificate(ctx, label); err != nil {
+			return nil, err
+		}
+	}
+	if c.pins != nil {
+		if err := c.pins.verify(signerCert); err != nil {
+			return nil, err
+		}
 	}
 
 	var ca, cert []byte
 	if c.bundle {
-		ca, cert, err = c.client.BundleSign(jsonData)
+		ca, cert, err = c.client.BundleSign(ctx, jsonData)
 	} else {
-		cert, err = c.client.Sign(jsonData)
+		if c.caSource != "" {
+			if ca, err = c.ca(signerCert); err != nil {
+				return nil, err
+			}
+		}
+		cert, err = c.client.Sign(ctx, jsonData)
 	}
 	if err != nil {
-		return nil, nil, fmt.Errorf("Error from cfssl API: %w", err)
+		return nil, fmt.Errorf("Error from cfssl API: %w", classify(err))
 	}
 
-	return ca, cert, nil
+	if c.pins != nil {
+		if err := c.pins.verifyIssued(cert, signerCert); err != nil {
+			return nil, err
+		}
+	}
+	return &SignResult{CA: ca, Certificate: cert, Label: label, Endpoint: c.client.Endpoint()}, nil
 }
@@ -0,0 +1,132 @@
+package signer
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// Used if the IssuerSpec does not configure the circuit breaker.
+	defaultCircuitBreakerFailureThreshold = 3
+	defaultCircuitBreakerCoolDown         = 30 * time.Second
+
+	// Weight of the latest request in the moving average of the latency.
+	latencyWeight = 0.3
+)
+
+var (
+	// now is replaced in tests
+	now = time.Now
+
+	// issuerEndpoints holds the state of the CFSSL API endpoints of every
+	// Issuer, keyed by IssuerData.IssuerKey. It outlives single reconciles, but
+	// is not shared between Issuers as they may use different credentials.
+	issuerEndpoints   = map[string]*endpointStates{}
+	issuerEndpointsMu sync.Mutex
+)
+
+// endpointStates is the state of the CFSSL API endpoints of one Issuer.
+type endpointStates struct {
+	// Comma separated list of URLs, as in the IssuerSpec
+	urls   string
+	states map[string]*endpointState
+	// Round robin position
+	roundRobin *uint64
+
+	mu sync.Mutex
+	// Name of the key the last successful authenticated request was made
+	// with, tried first by the next requests
+	acceptedKey string
+}
+
+// circuitBreaker takes an endpoint out of rotation for coolDown after
+// failureThreshold consecutive failed requests.
+type circuitBreaker struct {
+	failureThreshold int
+	coolDown         time.Duration
+}
+
+// endpointState is the observed health of a single CFSSL API endpoint.
+type endpointState struct {
+	mu                  sync.Mutex
+	consecutiveFailures int
+	lastFailure         time.Time
+	lastError           string
+	latency             time.Duration
+}
+
+// getEndpointStates returns the state of the endpoints of an Issuer. If its
+// URLs changed, the state of the ones no longer used is dropped.
+func getEndpointStates(issuerKey, urls string) *endpointStates {
+	issuerEndpointsMu.Lock()
+	defer issuerEndpointsMu.Unlock()
+	previous, ok := issuerEndpoints[issuerKey]
+	if ok && previous.urls == urls {
+		return previous
+	}
+	e := &endpointStates{urls: urls, states: map[string]*endpointState{}, roundRobin: new(uint64)}
+	if ok {
+		e.acceptedKey = previous.getAcceptedKey()
+	}
+	for _, u := range strings.Split(urls, ",") {
+		if ok && previous.states[u] != nil {
+			e.states[u] = previous.states[u]
+		} else {
+			e.states[u] = &endpointState{}
+		}
+	}
+	issuerEndpoints[issuerKey] = e
+	return e
+}
+
+func (e *endpointStates) getAcceptedKey() string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.acceptedKey
+}
+
+func (e *endpointStates) setAcceptedKey(name string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.acceptedKey = name
+}
+
+// ForgetIssuer drops the state of the endpoints of a deleted Issuer.
+func ForgetIssuer(issuerKey string) {
+	issuerEndpointsMu.Lock()
+	defer issuerEndpointsMu.Unlock()
+	delete(issuerEndpoints, issuerKey)
+}
+
+// record updates the state with the result of a request which took elapsed.
+func (s *endpointState) record(err error, elapsed time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if isEndpointFailure(err) {
+		s.consecutiveFailures++
+		s.lastFailure = now()
+		s.lastError = err.Error()
+		return
+	}
+	s.consecutiveFailures = 0
+	s.lastError = ""
+	if s.latency == 0 {
+		s.latency = elapsed
+	} else {
+		s.latency = time.Duration(latencyWeight*float64(elapsed) + (1-latencyWeight)*float64(s.latency))
+	}
+}
+
+// isOpen reports whether the endpoint is currently taken out of rotation by cb.
+func (s *endpointState) isOpen(cb circuitBreaker) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.consecutiveFailures >= cb.failureThreshold && now().Sub(s.lastFailure) < cb.coolDown
+}
+
+func (s *endpointState) getLatency() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.latency
+}
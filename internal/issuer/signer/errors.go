@@ -0,0 +1,154 @@
+package signer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	cfsslapi "github.com/cloudflare/cfssl/api"
+	cferr "github.com/cloudflare/cfssl/errors"
+)
+
+// ErrRequestRejected is returned by Sign if the CFSSL API rejected the request
+// for a reason that will not go away by retrying, like a policy violation.
+var ErrRequestRejected = errors.New("request rejected by the CFSSL API")
+
+var (
+	// ErrUnavailable is returned by health checks if none of the CFSSL API
+	// servers could be reached or all of them failed internally.
+	ErrUnavailable = errors.New("CFSSL API is unavailable")
+
+	// ErrLabelRejected is returned by health checks if the CFSSL API answered
+	// the info request for a label with an error, for example because it does
+	// not know the label.
+	ErrLabelRejected = errors.New("label rejected by the CFSSL API")
+)
+
+// apiError returns the error reported by the CFSSL API if err was returned by
+// the cfssl client because the API answered with an error response.
+func apiError(err error) (cfsslapi.ResponseMessage, bool) {
+	var cfErr *cferr.Error
+	if !errors.As(err, &cfErr) {
+		return cfsslapi.ResponseMessage{}, false
+	}
+	// Non 200 responses are wrapped by the client with the response body as message
+	var resp cfsslapi.Response
+	if json.Unmarshal([]byte(cfErr.Message), &resp) != nil || len(resp.Errors) == 0 {
+		// Responses with success set to false are wrapped with the error message only
+		if cfErr.ErrorCode == int(cferr.APIClientError)+int(cferr.ServerRequestFailed) {
+			return cfsslapi.ResponseMessage{Code: cfErr.ErrorCode, Message: cfErr.Message}, true
+		}
+		return cfsslapi.ResponseMessage{}, false
+	}
+	return resp.Errors[0], true
+}
+
+// isEndpointFailure reports whether err indicates that a CFSSL API endpoint is
+// unavailable, as opposed to the API rejecting a particular request.
+func isEndpointFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg, ok := apiError(err)
+	if !ok {
+		return true
+	}
+	// The API reports internal errors with the code 0 or the HTTP status code
+	return msg.Code == 0 || msg.Code >= http.StatusInternalServerError && msg.Code < 600
+}
+
+// isAuthFailure reports whether err indicates that the CFSSL API rejected the
+// token of an authenticated request. Depending on the server, this is reported
+// with the HTTP status 401 or the message "invalid token".
+func isAuthFailure(err error) bool {
+	msg, ok := apiError(err)
+	if !ok {
+		return false
+	}
+	return msg.Code == http.StatusUnauthorized || strings.Contains(msg.Message, "invalid token")
+}
+
+// isPermanent reports whether err indicates that the CFSSL API rejected a
+// request in a way that retrying it will not change.
+func isPermanent(err error) bool {
+	msg, ok := apiError(err)
+	if !ok {
+		return false
+	}
+	// HTTP status codes, like the ones used for authentication failures, end
+	// up in the Success category here and are treated as transient.
+	switch cferr.Category(msg.Code / 1000 * 1000) {
+	case cferr.PolicyError, cferr.CSRError:
+		return true
+	case cferr.CertificateError:
+		reason := cferr.Reason(msg.Code % 1000 / 100 * 100)
+		return reason == cferr.BadRequest || reason == cferr.MissingSerial
+	}
+	return false
+}
+
+// isSignerFailure reports whether err indicates that signing failed because of
+// the signer of a label, so that the signer of another label might succeed.
+// Requests which could not be sent at all or were rejected are not, nor are
+// authentication failures, as all labels are signed with the same keys.
+func isSignerFailure(err error) bool {
+	if errors.Is(err, ErrCAPinMismatch) {
+		return true
+	}
+	_, ok := apiError(err)
+	return ok && !isPermanent(err) && !isAuthFailure(err)
+}
+
+// classifyCheck wraps the error of a failed health check in ErrUnavailable or
+// ErrLabelRejected, depending on whether the CFSSL API answered it.
+func classifyCheck(err error) error {
+	if errors.Is(err, ErrCAPinMismatch) || errors.Is(err, errSignerCertificate) {
+		return err
+	}
+	if isEndpointFailure(err) {
+		return fmt.Errorf("%w: %w", ErrUnavailable, err)
+	}
+	if _, ok := apiError(err); ok {
+		return fmt.Errorf("%w: %w", ErrLabelRejected, err)
+	}
+	return err
+}
+
+// classify wraps permanent errors returned by the CFSSL API in ErrRequestRejected.
+func classify(err error) error {
+	if !isPermanent(err) {
+		return err
+	}
+	msg, _ := apiError(err)
+	return fmt.Errorf("%w: %s (code %d)", ErrRequestRejected, msg.Message, msg.Code)
+}
+
+// ErrorClass returns a short name for the kind of an error returned by Sign,
+// suitable as a metric label. It is empty for nil.
+func ErrorClass(err error) string {
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, ErrInvalidCSR):
+		return "invalid_csr"
+	case errors.Is(err, ErrRequestRejected):
+		return "rejected"
+	case errors.Is(err, ErrCAPinMismatch):
+		return "ca_pin_mismatch"
+	case errors.Is(err, errUntrustedSigner):
+		return "untrusted_signer"
+	case errors.Is(err, errUnknownLabel):
+		return "unknown_label"
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, context.Canceled):
+		return "timeout"
+	case isAuthFailure(err):
+		return "authentication"
+	case isEndpointFailure(err):
+		return "unavailable"
+	}
+	// The CFSSL API answered with an error which may go away on retry
+	return "api_error"
+}
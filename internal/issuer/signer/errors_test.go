@@ -0,0 +1,145 @@
+package signer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	cfsslapi "github.com/cloudflare/cfssl/api"
+	cferr "github.com/cloudflare/cfssl/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestAPIError returns the error the cfssl client returns if the CFSSL API
+// responds with the given status and error code.
+func newTestAPIError(status, code int, message string) error {
+	body, _ := json.Marshal(cfsslapi.NewErrorResponse(message, code))
+	if status == http.StatusOK {
+		return cferr.Wrap(cferr.APIClientError, cferr.ServerRequestFailed, errors.New(message))
+	}
+	return cferr.Wrap(cferr.APIClientError, cferr.ClientHTTPError, errors.New(string(body)))
+}
+
+func TestErrorClassification(t *testing.T) {
+	type testCase struct {
+		err                     error
+		expectedPermanent       bool
+		expectedEndpointFailure bool
+	}
+	tests := map[string]testCase{
+		"nil": {
+			err: nil,
+		},
+		"policy-violation": {
+			err:               newTestAPIError(http.StatusBadRequest, int(cferr.PolicyError)+int(cferr.InvalidRequest), "policy violation"),
+			expectedPermanent: true,
+		},
+		"unknown-profile": {
+			err:               newTestAPIError(http.StatusBadRequest, int(cferr.PolicyError)+int(cferr.UnknownProfile), "unknown profile"),
+			expectedPermanent: true,
+		},
+		"bad-csr": {
+			err:               newTestAPIError(http.StatusBadRequest, int(cferr.CSRError)+int(cferr.DecodeFailed), "bad csr"),
+			expectedPermanent: true,
+		},
+		"certificate-bad-request": {
+			err:               newTestAPIError(http.StatusBadRequest, int(cferr.CertificateError)+int(cferr.BadRequest), "bad request"),
+			expectedPermanent: true,
+		},
+		"certificate-unknown": {
+			err: newTestAPIError(http.StatusBadRequest, int(cferr.CertificateError)+int(cferr.Unknown), "signing failed"),
+		},
+		"invalid-token": {
+			err: newTestAPIError(http.StatusBadRequest, http.StatusBadRequest, "invalid token"),
+		},
+		"request-failed": {
+			err: newTestAPIError(http.StatusOK, 0, "request failed"),
+		},
+		"internal-error": {
+			err:                     newTestAPIError(http.StatusInternalServerError, 0, "internal error"),
+			expectedEndpointFailure: true,
+		},
+		"gateway-error": {
+			err:                     cferr.Wrap(cferr.APIClientError, cferr.ClientHTTPError, errors.New("<html>Bad Gateway</html>")),
+			expectedEndpointFailure: true,
+		},
+		"connection-refused": {
+			err:                     cferr.Wrap(cferr.APIClientError, cferr.ClientHTTPError, fmt.Errorf("failed POST to https://cfssl.example.com: connection refused")),
+			expectedEndpointFailure: true,
+		},
+		"other": {
+			err:                     errors.New("something else"),
+			expectedEndpointFailure: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedPermanent, isPermanent(tc.err), "unexpected isPermanent")
+			assert.Equal(t, tc.expectedEndpointFailure, isEndpointFailure(tc.err), "unexpected isEndpointFailure")
+			if tc.expectedPermanent {
+				assert.ErrorIs(t, classify(tc.err), ErrRequestRejected)
+			} else {
+				assert.Equal(t, tc.err, classify(tc.err))
+			}
+		})
+	}
+}
+
+func TestErrorClass(t *testing.T) {
+	tests := map[string]struct {
+		err           error
+		expectedClass string
+	}{
+		"nil": {
+			err: nil,
+		},
+		"invalid-csr": {
+			err:           fmt.Errorf("%w: no PEM block", ErrInvalidCSR),
+			expectedClass: "invalid_csr",
+		},
+		"rejected": {
+			err:           classify(newTestAPIError(http.StatusBadRequest, int(cferr.PolicyError)+int(cferr.InvalidRequest), "policy violation")),
+			expectedClass: "rejected",
+		},
+		"ca-pin-mismatch": {
+			err:           ErrCAPinMismatch,
+			expectedClass: "ca_pin_mismatch",
+		},
+		"untrusted-signer": {
+			err:           fmt.Errorf("%w: unknown authority", errUntrustedSigner),
+			expectedClass: "untrusted_signer",
+		},
+		"unknown-label": {
+			err:           fmt.Errorf("%w: %q", errUnknownLabel, "foo"),
+			expectedClass: "unknown_label",
+		},
+		"timeout": {
+			err:           fmt.Errorf("%w, last error: connection refused", context.DeadlineExceeded),
+			expectedClass: "timeout",
+		},
+		"invalid-token": {
+			err:           newTestAPIError(http.StatusBadRequest, http.StatusBadRequest, "invalid token"),
+			expectedClass: "authentication",
+		},
+		"internal-error": {
+			err:           fmt.Errorf("Error from cfssl API: %w", newTestAPIError(http.StatusInternalServerError, 0, "internal error")),
+			expectedClass: "unavailable",
+		},
+		"connection-refused": {
+			err:           fmt.Errorf("%w: %w", errSignerCertificate, cferr.Wrap(cferr.APIClientError, cferr.ClientHTTPError, errors.New("connection refused"))),
+			expectedClass: "unavailable",
+		},
+		"signing-failed": {
+			err:           newTestAPIError(http.StatusBadRequest, int(cferr.CertificateError)+int(cferr.Unknown), "signing failed"),
+			expectedClass: "api_error",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedClass, ErrorClass(tc.err))
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package signer
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	cfsslissuerapi "gerrit.wikimedia.org/r/operations/software/cfssl-issuer/api/v1alpha1"
+)
+
+var (
+	errInvalidCAPin = errors.New("invalid CA pin, expected a hex encoded SHA-256 fingerprint")
+
+	// ErrCAPinMismatch is returned if the signer certificate does not match
+	// any of the CA pins of the Issuer.
+	ErrCAPinMismatch = errors.New("signer certificate does not match any CA pin")
+)
+
+// caPins holds the SHA-256 fingerprints the signer certificate is expected to match.
+type caPins struct {
+	spki map[[sha256.Size]byte]bool
+	cert map[[sha256.Size]byte]bool
+}
+
+// newCAPins returns nil if config does not contain any pins.
+func newCAPins(config *cfsslissuerapi.CAPins) (*caPins, error) {
+	if config == nil || len(config.SPKISHA256)+len(config.CertificateSHA256) == 0 {
+		return nil, nil
+	}
+	var err error
+	p := &caPins{}
+	if p.spki, err = parseFingerprints(config.SPKISHA256); err != nil {
+		return nil, err
+	}
+	if p.cert, err = parseFingerprints(config.CertificateSHA256); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// parseFingerprints parses hex encoded fingerprints, optionally separated by colons.
+func parseFingerprints(fingerprints []string) (map[[sha256.Size]byte]bool, error) {
+	parsed := make(map[[sha256.Size]byte]bool, len(fingerprints))
+	for _, f := range fingerprints {
+		b, err := hex.DecodeString(strings.ReplaceAll(f, ":", ""))
+		if err != nil || len(b) != sha256.Size {
+			return nil, fmt.Errorf("%w: %q", errInvalidCAPin, f)
+		}
+		parsed[[sha256.Size]byte(b)] = true
+	}
+	return parsed, nil
+}
+
+// verify checks that cert matches at least one of the pins.
+func (p *caPins) verify(cert *x509.Certificate) error {
+	if p.spki[sha256.Sum256(cert.RawSubjectPublicKeyInfo)] || p.cert[sha256.Sum256(cert.Raw)] {
+		return nil
+	}
+	return fmt.Errorf("%w: %q (SPKI SHA-256 %x)", ErrCAPinMismatch, cert.Subject, sha256.Sum256(cert.RawSubjectPublicKeyInfo))
+}
+
+// verifyIssued checks that the PEM encoded certificate (chain) was signed by
+// the pinned signerCert.
+func (p *caPins) verifyIssued(certPEM []byte, signerCert *x509.Certificate) error {
+	leaf, err := parseCertificate(certPEM)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrCAPinMismatch, err)
+	}
+	if err := leaf.CheckSignatureFrom(signerCert); err != nil {
+		return fmt.Errorf("%w: issued certificate was not signed by the signer certificate: %v", ErrCAPinMismatch, err)
+	}
+	return nil
+}
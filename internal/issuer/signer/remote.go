@@ -0,0 +1,303 @@
+package signer
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	cfsslissuerapi "gerrit.wikimedia.org/r/operations/software/cfssl-issuer/api/v1alpha1"
+	cfsslclient "github.com/cloudflare/cfssl/api/client"
+	cfsslauth "github.com/cloudflare/cfssl/auth"
+	cfsslinfo "github.com/cloudflare/cfssl/info"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var (
+	errInvalidURL = errors.New("invalid CFSSL API URL")
+)
+
+// authInfoer is implemented by the (unexported) single server Remote of the
+// cfssl client, but not by the Remote interface itself.
+type authInfoer interface {
+	AuthInfo(req, id []byte, provider cfsslauth.Provider) ([]byte, error)
+}
+
+// authRemote is a BasicRemote performing authenticated requests against one
+// or more CFSSL API servers. Servers are tried in the order given by the
+// strategy until one of them succeeds, skipping servers whose circuit breaker
+// is open unless the breakers of all servers are.
+// If the CFSSL API rejects the token of an authenticated request, it is retried
+// with the next key. The key last accepted for the Issuer is tried first.
+//
+// The cfssl client has no notion of a context, so it is attached to the
+// outgoing HTTP requests via a request modifier. An authRemote must therefore
+// not be used concurrently.
+type authRemote struct {
+	endpoints []*endpoint
+	keys      []authKey
+	strategy  cfsslissuerapi.URLStrategy
+	breaker   circuitBreaker
+	// Shared by all authRemotes of the same Issuer
+	states *endpointStates
+	// URL of the endpoint which answered the last request
+	answeredBy string
+}
+
+// authKey is a key used to authenticate requests to the CFSSL API.
+type authKey struct {
+	// Name of the field of the auth Secret containing the key.
+	name     string
+	provider cfsslauth.Provider
+}
+
+// endpoint is a single CFSSL API server of an authRemote.
+type endpoint struct {
+	url    string
+	server cfsslclient.Remote
+	state  *endpointState
+}
+
+func newAuthRemote(issuerKey, urls string, tlsConfig *tls.Config, keys []authKey, timeout time.Duration, strategy cfsslissuerapi.URLStrategy, breaker circuitBreaker) (*authRemote, error) {
+	states := getEndpointStates(issuerKey, urls)
+	r := &authRemote{
+		keys:     keys,
+		strategy: strategy,
+		breaker:  breaker,
+		states:   states,
+	}
+	for _, u := range strings.Split(urls, ",") {
+		srv := cfsslclient.NewServerTLS(u, tlsConfig)
+		if srv == nil {
+			return nil, fmt.Errorf("%w: %q", errInvalidURL, u)
+		}
+		srv.SetRequestTimeout(timeout)
+		r.endpoints = append(r.endpoints, &endpoint{
+			url:    u,
+			server: srv,
+			state:  states.states[u],
+		})
+	}
+	return r, nil
+}
+
+// ordered returns the endpoints in the order they should be tried.
+func (r *authRemote) ordered() []*endpoint {
+	eps := make([]*endpoint, len(r.endpoints))
+	switch r.strategy {
+	case cfsslissuerapi.URLStrategyRoundRobin:
+		start := int((atomic.AddUint64(r.states.roundRobin, 1) - 1) % uint64(len(r.endpoints)))
+		n := copy(eps, r.endpoints[start:])
+		copy(eps[n:], r.endpoints[:start])
+	case cfsslissuerapi.URLStrategyLowestLatency:
+		copy(eps, r.endpoints)
+		// Endpoints without a known latency are tried first, so they get one
+		sort.SliceStable(eps, func(i, j int) bool {
+			return eps[i].state.getLatency() < eps[j].state.getLatency()
+		})
+	default:
+		copy(eps, r.endpoints)
+	}
+
+	closed := make([]*endpoint, 0, len(eps))
+	for _, ep := range eps {
+		if !ep.state.isOpen(r.breaker) {
+			closed = append(closed, ep)
+		}
+	}
+	if len(closed) == 0 {
+		return eps
+	}
+	return closed
+}
+
+// each calls fn for the endpoints in order until it succeeds, the CFSSL API
+// rejects the request or ctx is done.
+func (r *authRemote) each(ctx context.Context, fn func(srv cfsslclient.Remote) error) error {
+	var err error
+	r.answeredBy = ""
+	for _, ep := range r.ordered() {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			if err == nil {
+				return ctxErr
+			}
+			return fmt.Errorf("%w, last error: %v", ctxErr, err)
+		}
+		if err = r.call(ctx, ep, fn); !isEndpointFailure(err) {
+			r.answeredBy = ep.url
+			return err
+		}
+	}
+	return err
+}
+
+// authenticated calls each with fn for the keys, starting with the one last
+// accepted, until the CFSSL API does not reject the token of a key.
+func (r *authRemote) authenticated(ctx context.Context, fn func(srv cfsslclient.Remote, provider cfsslauth.Provider) error) error {
+	keys := r.orderedKeys()
+	var err error
+	for i, key := range keys {
+		err = r.each(ctx, func(srv cfsslclient.Remote) error {
+			return fn(srv, key.provider)
+		})
+		if err == nil {
+			r.states.setAcceptedKey(key.name)
+			return nil
+		}
+		if !isAuthFailure(err) {
+			return err
+		}
+		if i == len(keys)-1 {
+			r.states.setAcceptedKey("")
+		}
+	}
+	return err
+}
+
+// orderedKeys returns the keys with the one last accepted first, so that
+// requests do not keep trying a key which was already replaced.
+func (r *authRemote) orderedKeys() []authKey {
+	accepted := r.states.getAcceptedKey()
+	keys := make([]authKey, 0, len(r.keys))
+	for _, key := range r.keys {
+		if key.name == accepted {
+			keys = append(keys, key)
+		}
+	}
+	for _, key := range r.keys {
+		if key.name != accepted {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// AcceptedKey returns the name of the key the last successful authenticated
+// request of the Issuer was made with, by a signing request or health check,
+// empty if there was none or the keys were rejected since.
+func (r *authRemote) AcceptedKey() string {
+	return r.states.getAcceptedKey()
+}
+
+// Endpoint returns the URL of the endpoint which answered the last request,
+// successfully or not, empty if none did. For CheckEndpoints, it is the one
+// whose response was returned.
+func (r *authRemote) Endpoint() string {
+	return r.answeredBy
+}
+
+// call calls fn for a single endpoint and records the result in its state.
+func (r *authRemote) call(ctx context.Context, ep *endpoint, fn func(srv cfsslclient.Remote) error) error {
+	ep.server.SetReqModifier(func(req *http.Request, _ []byte) {
+		*req = *req.WithContext(ctx)
+	})
+	start := now()
+	err := fn(ep.server)
+	// Don't blame the endpoint for requests we gave up on ourselves
+	if ctx.Err() == nil {
+		ep.state.record(err, now().Sub(start))
+	}
+	return err
+}
+
+// CheckEndpoints sends an info request to every endpoint, regardless of the
+// state of their circuit breakers, and returns their health along with the
+// response of the first endpoint which succeeded. An error is returned if none
+// of the requests succeeded.
+func (r *authRemote) CheckEndpoints(ctx context.Context, jsonData []byte) (*cfsslinfo.Resp, []cfsslissuerapi.EndpointStatus, error) {
+	statuses := make([]cfsslissuerapi.EndpointStatus, 0, len(r.endpoints))
+	var resp *cfsslinfo.Resp
+	var lastErr error
+	r.answeredBy = ""
+	for _, ep := range r.endpoints {
+		err := r.call(ctx, ep, func(srv cfsslclient.Remote) error {
+			epResp, err := srv.Info(jsonData)
+			if err == nil && resp == nil {
+				resp = epResp
+				r.answeredBy = ep.url
+			}
+			return err
+		})
+		if err != nil {
+			lastErr = err
+		}
+		statuses = append(statuses, ep.status(err, r.breaker))
+	}
+	if resp != nil {
+		return resp, statuses, nil
+	}
+	return nil, statuses, lastErr
+}
+
+func (ep *endpoint) status(err error, cb circuitBreaker) cfsslissuerapi.EndpointStatus {
+	open := ep.state.isOpen(cb)
+	ep.state.mu.Lock()
+	defer ep.state.mu.Unlock()
+	status := cfsslissuerapi.EndpointStatus{
+		URL:                 ep.url,
+		Healthy:             !isEndpointFailure(err),
+		CircuitOpen:         open,
+		ConsecutiveFailures: int32(ep.state.consecutiveFailures),
+		LastError:           ep.state.lastError,
+	}
+	if ep.state.latency > 0 {
+		status.Latency = &metav1.Duration{Duration: ep.state.latency.Round(time.Millisecond)}
+	}
+	if err != nil && status.LastError == "" {
+		status.LastError = err.Error()
+	}
+	return status
+}
+
+func (r *authRemote) Sign(ctx context.Context, jsonData []byte) (cert []byte, err error) {
+	err = r.authenticated(ctx, func(srv cfsslclient.Remote, provider cfsslauth.Provider) error {
+		cert, err = srv.AuthSign(jsonData, nil, provider)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cert, nil
+}
+
+func (r *authRemote) BundleSign(ctx context.Context, jsonData []byte) (ca []byte, cert []byte, err error) {
+	err = r.authenticated(ctx, func(srv cfsslclient.Remote, provider cfsslauth.Provider) error {
+		ca, cert, err = srv.BundleAuthSign(jsonData, nil, provider)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return ca, cert, nil
+}
+
+func (r *authRemote) Info(ctx context.Context, jsonData []byte) (resp *cfsslinfo.Resp, err error) {
+	err = r.each(ctx, func(srv cfsslclient.Remote) error {
+		resp, err = srv.Info(jsonData)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (r *authRemote) AuthInfo(ctx context.Context, jsonData []byte) (cert []byte, err error) {
+	err = r.authenticated(ctx, func(srv cfsslclient.Remote, provider cfsslauth.Provider) error {
+		s, ok := srv.(authInfoer)
+		if !ok {
+			return fmt.Errorf("cfssl client for %v does not support authenticated info requests", srv.Hosts())
+		}
+		cert, err = s.AuthInfo(jsonData, nil, provider)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cert, nil
+}
@@ -0,0 +1,315 @@
+package signer
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	cfsslissuerapi "gerrit.wikimedia.org/r/operations/software/cfssl-issuer/api/v1alpha1"
+	cfsslauth "github.com/cloudflare/cfssl/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCfsslAPI(t *testing.T, handler http.HandlerFunc) *httptest.Server {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func infoHandler(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write([]byte(`{"success":true,"result":{"certificate":"cert","usages":["signing"],"expiry":"1h"},"errors":[],"messages":[]}`))
+}
+
+// rejectHandler answers like the CFSSL API does for requests it rejects.
+func rejectHandler(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	_, _ = w.Write([]byte(`{"success":false,"result":null,"errors":[{"code":5400,"message":"unknown profile"}],"messages":[]}`))
+}
+
+// authHandler answers like the CFSSL API does for authenticated info requests,
+// accepting only tokens generated with key.
+func authHandler(t *testing.T, key string) http.HandlerFunc {
+	provider, err := cfsslauth.New(key, nil)
+	require.NoError(t, err)
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req cfsslauth.AuthenticatedRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || !provider.Verify(&req) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"success":false,"result":null,"errors":[{"code":400,"message":"invalid token"}],"messages":[]}`))
+			return
+		}
+		infoHandler(w, r)
+	}
+}
+
+func newTestAuthRemote(t *testing.T, urls string, timeout time.Duration) *authRemote {
+	return newTestAuthRemoteWithStrategy(t, urls, timeout, cfsslissuerapi.URLStrategyOrderedList)
+}
+
+func newTestAuthRemoteWithStrategy(t *testing.T, urls string, timeout time.Duration, strategy cfsslissuerapi.URLStrategy) *authRemote {
+	keys, err := newAuthKeys(map[string][]byte{"key": []byte("b8093a819f367241a8e0f55125589e25")})
+	require.NoError(t, err)
+	return newTestAuthRemoteWithKeys(t, urls, timeout, strategy, keys)
+}
+
+func newTestAuthRemoteWithKeys(t *testing.T, urls string, timeout time.Duration, strategy cfsslissuerapi.URLStrategy, keys []authKey) *authRemote {
+	// Endpoint state is kept per Issuer, don't leak it between tests
+	t.Cleanup(func() { ForgetIssuer(t.Name()) })
+	r, err := newAuthRemote(t.Name(), urls, &tls.Config{}, keys, timeout, strategy, circuitBreaker{
+		failureThreshold: defaultCircuitBreakerFailureThreshold,
+		coolDown:         defaultCircuitBreakerCoolDown,
+	})
+	require.NoError(t, err)
+	return r
+}
+
+func TestAuthRemoteOrderedFailover(t *testing.T) {
+	var failingCalls int
+	failing := newTestCfsslAPI(t, func(w http.ResponseWriter, _ *http.Request) {
+		failingCalls++
+		w.WriteHeader(http.StatusBadGateway)
+	})
+	working := newTestCfsslAPI(t, infoHandler)
+
+	r := newTestAuthRemote(t, failing.URL+","+working.URL, time.Second)
+	resp, err := r.Info(context.Background(), []byte(`{"label":"foo"}`))
+	require.NoError(t, err)
+	assert.Equal(t, "cert", resp.Certificate)
+	assert.Equal(t, 1, failingCalls)
+	assert.Equal(t, working.URL, r.Endpoint())
+}
+
+func TestAuthRemoteContext(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	hanging := newTestCfsslAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	})
+
+	r := newTestAuthRemote(t, hanging.URL, time.Minute)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := r.Info(ctx, []byte(`{"label":"foo"}`))
+	assert.Error(t, err)
+	assert.Less(t, time.Since(start), 10*time.Second, "request was not cancelled")
+
+	// Further servers are not tried once the context is done
+	_, err = r.Info(ctx, []byte(`{"label":"foo"}`))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestAuthRemoteTimeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	hanging := newTestCfsslAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	})
+
+	r := newTestAuthRemote(t, hanging.URL, 100*time.Millisecond)
+	start := time.Now()
+	_, err := r.Info(context.Background(), []byte(`{"label":"foo"}`))
+	assert.Error(t, err)
+	assert.Less(t, time.Since(start), 10*time.Second, "request did not time out")
+}
+
+func TestNewAuthRemoteInvalidURL(t *testing.T) {
+	_, err := newAuthRemote(t.Name(), "https://valid.example.org,%zz", &tls.Config{}, nil, time.Second, cfsslissuerapi.URLStrategyOrderedList, circuitBreaker{})
+	assert.ErrorIs(t, err, errInvalidURL)
+}
+
+func TestAuthRemoteNoFailoverOnRejection(t *testing.T) {
+	rejecting := newTestCfsslAPI(t, rejectHandler)
+	var workingCalls int
+	working := newTestCfsslAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		workingCalls++
+		infoHandler(w, r)
+	})
+
+	r := newTestAuthRemote(t, rejecting.URL+","+working.URL, time.Second)
+	_, err := r.Info(context.Background(), []byte(`{"label":"foo"}`))
+	assert.Error(t, err)
+	assert.Equal(t, 0, workingCalls, "rejected request was sent to the next server")
+	assert.Equal(t, 0, r.endpoints[0].state.consecutiveFailures, "rejection counted as failure")
+	assert.Equal(t, rejecting.URL, r.Endpoint(), "rejecting server not reported as answering")
+}
+
+func TestAuthRemoteNextKey(t *testing.T) {
+	const (
+		currentKey = "b8093a819f367241a8e0f55125589e25"
+		nextKey    = "0123456789abcdef0123456789abcdef"
+	)
+	var calls int
+	srv := newTestCfsslAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		authHandler(t, nextKey)(w, r)
+	})
+	keys, err := newAuthKeys(map[string][]byte{
+		"key":      []byte(currentKey),
+		"key.next": []byte(nextKey),
+	})
+	require.NoError(t, err)
+
+	r := newTestAuthRemoteWithKeys(t, srv.URL, time.Second, cfsslissuerapi.URLStrategyOrderedList, keys)
+	assert.Empty(t, r.AcceptedKey())
+	_, err = r.AuthInfo(context.Background(), []byte(`{"label":"foo"}`))
+	require.NoError(t, err)
+	assert.Equal(t, "key.next", r.AcceptedKey())
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, 0, r.endpoints[0].state.consecutiveFailures, "rejected token counted as failure")
+
+	// The next requests of the Issuer start with the accepted key
+	calls = 0
+	r = newTestAuthRemoteWithKeys(t, srv.URL, time.Second, cfsslissuerapi.URLStrategyOrderedList, keys)
+	assert.Equal(t, "key.next", r.AcceptedKey())
+	_, err = r.AuthInfo(context.Background(), []byte(`{"label":"foo"}`))
+	require.NoError(t, err)
+	assert.Equal(t, 1, calls)
+
+	// Requests fail once neither key is accepted
+	keys, err = newAuthKeys(map[string][]byte{"key": []byte(currentKey)})
+	require.NoError(t, err)
+	r = newTestAuthRemoteWithKeys(t, srv.URL, time.Second, cfsslissuerapi.URLStrategyOrderedList, keys)
+	_, err = r.AuthInfo(context.Background(), []byte(`{"label":"foo"}`))
+	assert.Error(t, err)
+	assert.Empty(t, r.AcceptedKey())
+}
+
+func TestAuthRemoteRoundRobin(t *testing.T) {
+	calls := make([]int, 3)
+	var urls []string
+	for i := range calls {
+		i := i
+		srv := newTestCfsslAPI(t, func(w http.ResponseWriter, r *http.Request) {
+			calls[i]++
+			infoHandler(w, r)
+		})
+		urls = append(urls, srv.URL)
+	}
+
+	for i := 0; i < 6; i++ {
+		// Every reconcile builds a new authRemote, the position has to be shared
+		r := newTestAuthRemoteWithStrategy(t, strings.Join(urls, ","), time.Second, cfsslissuerapi.URLStrategyRoundRobin)
+		_, err := r.Info(context.Background(), []byte(`{"label":"foo"}`))
+		require.NoError(t, err)
+	}
+	assert.Equal(t, []int{2, 2, 2}, calls)
+}
+
+func TestAuthRemoteLowestLatency(t *testing.T) {
+	var slowCalls, fastCalls int
+	slow := newTestCfsslAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		slowCalls++
+		time.Sleep(50 * time.Millisecond)
+		infoHandler(w, r)
+	})
+	fast := newTestCfsslAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		fastCalls++
+		infoHandler(w, r)
+	})
+
+	r := newTestAuthRemoteWithStrategy(t, slow.URL+","+fast.URL, time.Second, cfsslissuerapi.URLStrategyLowestLatency)
+	// Measure the latency of both
+	_, _, err := r.CheckEndpoints(context.Background(), []byte(`{"label":"foo"}`))
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		_, err := r.Info(context.Background(), []byte(`{"label":"foo"}`))
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 1, slowCalls)
+	assert.Equal(t, 4, fastCalls)
+}
+
+func TestAuthRemoteCircuitBreaker(t *testing.T) {
+	defer func() { now = time.Now }()
+	current := time.Now()
+	now = func() time.Time { return current }
+
+	var failingCalls int
+	failing := newTestCfsslAPI(t, func(w http.ResponseWriter, _ *http.Request) {
+		failingCalls++
+		w.WriteHeader(http.StatusBadGateway)
+	})
+	working := newTestCfsslAPI(t, infoHandler)
+
+	r := newTestAuthRemote(t, failing.URL+","+working.URL, time.Second)
+	for i := 0; i < 5; i++ {
+		_, err := r.Info(context.Background(), []byte(`{"label":"foo"}`))
+		require.NoError(t, err)
+	}
+	assert.Equal(t, defaultCircuitBreakerFailureThreshold, failingCalls, "failing server was not taken out of rotation")
+
+	resp, statuses, err := r.CheckEndpoints(context.Background(), []byte(`{"label":"foo"}`))
+	require.NoError(t, err)
+	assert.Equal(t, "cert", resp.Certificate)
+	require.Len(t, statuses, 2)
+	assert.False(t, statuses[0].Healthy)
+	assert.True(t, statuses[0].CircuitOpen)
+	assert.Equal(t, int32(defaultCircuitBreakerFailureThreshold+1), statuses[0].ConsecutiveFailures)
+	assert.NotEmpty(t, statuses[0].LastError)
+	assert.True(t, statuses[1].Healthy)
+	assert.False(t, statuses[1].CircuitOpen)
+
+	// The server is tried again after the cool down
+	failingCalls = 0
+	current = current.Add(defaultCircuitBreakerCoolDown)
+	_, err = r.Info(context.Background(), []byte(`{"label":"foo"}`))
+	require.NoError(t, err)
+	assert.Equal(t, 1, failingCalls)
+}
+
+func TestAuthRemoteAllCircuitsOpen(t *testing.T) {
+	var calls int
+	failing := newTestCfsslAPI(t, func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusBadGateway)
+	})
+
+	r := newTestAuthRemote(t, failing.URL, time.Second)
+	for i := 0; i < defaultCircuitBreakerFailureThreshold+2; i++ {
+		_, err := r.Info(context.Background(), []byte(`{"label":"foo"}`))
+		assert.Error(t, err)
+	}
+	assert.Equal(t, defaultCircuitBreakerFailureThreshold+2, calls, "servers are tried if all circuits are open")
+}
+
+func TestEndpointStatesPerIssuer(t *testing.T) {
+	t.Cleanup(func() {
+		ForgetIssuer("Issuer/ns1/issuer1")
+		ForgetIssuer("Issuer/ns2/issuer1")
+	})
+	const urls = "https://cfssl1.example.com,https://cfssl2.example.com"
+	issuer1 := getEndpointStates("Issuer/ns1/issuer1", urls)
+	issuer1.states["https://cfssl1.example.com"].record(errors.New("connection refused"), 0)
+
+	// Another Issuer using the same URLs does not see the failure
+	other := getEndpointStates("Issuer/ns2/issuer1", urls)
+	assert.Zero(t, other.states["https://cfssl1.example.com"].consecutiveFailures)
+	assert.NotSame(t, issuer1.roundRobin, other.roundRobin)
+
+	// The state is kept as long as an URL is used by the Issuer
+	assert.Same(t, issuer1, getEndpointStates("Issuer/ns1/issuer1", urls))
+	changed := getEndpointStates("Issuer/ns1/issuer1", "https://cfssl1.example.com")
+	assert.Equal(t, 1, changed.states["https://cfssl1.example.com"].consecutiveFailures)
+	assert.Len(t, changed.states, 1)
+
+	ForgetIssuer("Issuer/ns1/issuer1")
+	assert.Zero(t, getEndpointStates("Issuer/ns1/issuer1", urls).states["https://cfssl1.example.com"].consecutiveFailures)
+}
@@ -0,0 +1,43 @@
+package signer
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+var (
+	errInvalidCABundle          = errors.New("CA bundle does not contain any valid PEM encoded certificate")
+	errInvalidClientCertificate = errors.New("failed to load client certificate")
+)
+
+// newTLSConfig returns the TLS configuration used to talk to the CFSSL API.
+// If caBundle is empty, the system certificate pool is trusted.
+// If clientCertData is not empty, it is expected to contain the keys of a
+// "kubernetes.io/tls" Secret and the certificate is presented to the server.
+func newTLSConfig(caBundle []byte, clientCertData map[string][]byte) (*tls.Config, error) {
+	var rootCAs *x509.CertPool
+	if len(caBundle) == 0 {
+		rootCAs, _ = x509.SystemCertPool()
+	} else {
+		rootCAs = x509.NewCertPool()
+		if !rootCAs.AppendCertsFromPEM(caBundle) {
+			return nil, errInvalidCABundle
+		}
+	}
+	tlsconfig := &tls.Config{
+		RootCAs: rootCAs,
+	}
+
+	if len(clientCertData) > 0 {
+		cert, err := tls.X509KeyPair(clientCertData[corev1.TLSCertKey], clientCertData[corev1.TLSPrivateKeyKey])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", errInvalidClientCertificate, err)
+		}
+		tlsconfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsconfig, nil
+}
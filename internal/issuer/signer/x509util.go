@@ -20,17 +20,45 @@ import (
 	"crypto/x509"
 	"encoding/pem"
 	"errors"
+	"fmt"
 )
 
 var (
-	errInvalidCSR = errors.New("PEM block type must be CERTIFICATE REQUEST")
+	// ErrInvalidCSR is returned by ParseCSR if the CSR cannot be parsed or
+	// its signature is invalid.
+	ErrInvalidCSR         = errors.New("invalid certificate signing request")
+	errInvalidCertificate = errors.New("PEM block type must be CERTIFICATE")
 )
 
-func parseCSR(pemBytes []byte) (*x509.CertificateRequest, error) {
+// ParseCSR parses a PEM encoded CSR and verifies its signature.
+func ParseCSR(pemBytes []byte) (*x509.CertificateRequest, error) {
 	// extract PEM from request object
 	block, _ := pem.Decode(pemBytes)
 	if block == nil || block.Type != "CERTIFICATE REQUEST" {
-		return nil, errInvalidCSR
+		return nil, fmt.Errorf("%w: PEM block type must be CERTIFICATE REQUEST", ErrInvalidCSR)
 	}
-	return x509.ParseCertificateRequest(block.Bytes)
+	csr, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCSR, err)
+	}
+	if err := csr.CheckSignature(); err != nil {
+		return nil, fmt.Errorf("%w: invalid signature: %v", ErrInvalidCSR, err)
+	}
+	return csr, nil
+}
+
+func parseCertificate(pemBytes []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, errInvalidCertificate
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+func encodeCertificates(certs []*x509.Certificate) []byte {
+	var pemBytes []byte
+	for _, cert := range certs {
+		pemBytes = append(pemBytes, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})...)
+	}
+	return pemBytes
 }
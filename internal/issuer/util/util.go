@@ -20,6 +20,7 @@ package util
 import (
 	"fmt"
 
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -37,42 +38,63 @@ func GetSpecAndStatus(issuer client.Object) (*cfsslissuerapi.IssuerSpec, *cfssli
 	}
 }
 
-func SetReadyCondition(status *cfsslissuerapi.IssuerStatus, conditionStatus cfsslissuerapi.ConditionStatus, reason, message string) {
-	ready := GetReadyCondition(status)
-	if ready == nil {
-		ready = &cfsslissuerapi.IssuerCondition{
-			Type: cfsslissuerapi.IssuerConditionReady,
-		}
-		status.Conditions = append(status.Conditions, *ready)
-	}
-	if ready.Status != conditionStatus {
-		ready.Status = conditionStatus
-		now := metav1.Now()
-		ready.LastTransitionTime = &now
-	}
-	ready.Reason = reason
-	ready.Message = message
+func SetReadyCondition(status *cfsslissuerapi.IssuerStatus, generation int64, conditionStatus metav1.ConditionStatus, reason cfsslissuerapi.IssuerConditionReason, message string) {
+	SetCondition(status, generation, cfsslissuerapi.IssuerConditionReady, conditionStatus, reason, message)
+}
 
-	for i, c := range status.Conditions {
-		if c.Type == cfsslissuerapi.IssuerConditionReady {
-			status.Conditions[i] = *ready
-			return
-		}
+func GetReadyCondition(status *cfsslissuerapi.IssuerStatus) *metav1.Condition {
+	return GetCondition(status, cfsslissuerapi.IssuerConditionReady)
+}
+
+// SetCondition adds or updates the condition of the given type, observed for
+// the given generation of the issuer. The LastTransitionTime is only updated
+// if the status of the condition changes.
+func SetCondition(status *cfsslissuerapi.IssuerStatus, generation int64, conditionType string, conditionStatus metav1.ConditionStatus, reason cfsslissuerapi.IssuerConditionReason, message string) {
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             conditionStatus,
+		ObservedGeneration: generation,
+		Reason:             string(reason),
+		Message:            message,
+	})
+}
+
+// GetCondition returns a copy of the condition of the given type, nil if
+// there is none.
+func GetCondition(status *cfsslissuerapi.IssuerStatus, conditionType string) *metav1.Condition {
+	if c := meta.FindStatusCondition(status.Conditions, conditionType); c != nil {
+		condition := *c
+		return &condition
 	}
+	return nil
+}
+
+// RemoveCondition removes the condition of the given type, if there is one.
+func RemoveCondition(status *cfsslissuerapi.IssuerStatus, conditionType string) {
+	meta.RemoveStatusCondition(&status.Conditions, conditionType)
 }
 
-func GetReadyCondition(status *cfsslissuerapi.IssuerStatus) *cfsslissuerapi.IssuerCondition {
-	for _, c := range status.Conditions {
-		if c.Type == cfsslissuerapi.IssuerConditionReady {
-			return &c
+// ConvertConditions converts conditions written before the status used
+// metav1.Condition, which did not require a LastTransitionTime, Reason or
+// Status, so that the status passes validation when it is updated.
+func ConvertConditions(status *cfsslissuerapi.IssuerStatus, now metav1.Time) {
+	for i := range status.Conditions {
+		c := &status.Conditions[i]
+		if c.LastTransitionTime.IsZero() {
+			c.LastTransitionTime = now
+		}
+		if c.Status == "" {
+			c.Status = metav1.ConditionUnknown
+		}
+		if c.Reason == "" {
+			c.Reason = string(cfsslissuerapi.IssuerReasonUnspecified)
 		}
 	}
-	return nil
 }
 
 func IsReady(status *cfsslissuerapi.IssuerStatus) bool {
 	if c := GetReadyCondition(status); c != nil {
-		return c.Status == cfsslissuerapi.ConditionTrue
+		return c.Status == metav1.ConditionTrue
 	}
 	return false
 }
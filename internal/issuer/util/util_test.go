@@ -19,8 +19,10 @@ package util
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	cfsslissuerapi "gerrit.wikimedia.org/r/operations/software/cfssl-issuer/api/v1alpha1"
 )
@@ -28,9 +30,61 @@ import (
 func TestSetReadyCondition(t *testing.T) {
 	var issuerStatus cfsslissuerapi.IssuerStatus
 
-	SetReadyCondition(&issuerStatus, cfsslissuerapi.ConditionTrue, "reason1", "message1")
+	SetReadyCondition(&issuerStatus, 1, metav1.ConditionTrue, "reason1", "message1")
 	assert.Equal(t, "message1", GetReadyCondition(&issuerStatus).Message)
 
-	SetReadyCondition(&issuerStatus, cfsslissuerapi.ConditionFalse, "reason2", "message2")
+	SetReadyCondition(&issuerStatus, 1, metav1.ConditionFalse, "reason2", "message2")
 	assert.Equal(t, "message2", GetReadyCondition(&issuerStatus).Message)
 }
+
+func TestSetCondition(t *testing.T) {
+	var issuerStatus cfsslissuerapi.IssuerStatus
+
+	SetReadyCondition(&issuerStatus, 1, metav1.ConditionTrue, "reason1", "message1")
+	SetCondition(&issuerStatus, 1, cfsslissuerapi.IssuerConditionCAExpiringSoon, metav1.ConditionFalse, "reason2", "message2")
+	assert.Len(t, issuerStatus.Conditions, 2)
+	assert.Equal(t, "message1", GetReadyCondition(&issuerStatus).Message)
+
+	SetCondition(&issuerStatus, 1, cfsslissuerapi.IssuerConditionCAExpiringSoon, metav1.ConditionTrue, "reason3", "message3")
+	assert.Len(t, issuerStatus.Conditions, 2)
+	condition := GetCondition(&issuerStatus, cfsslissuerapi.IssuerConditionCAExpiringSoon)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, "message3", condition.Message)
+
+	// Conditions record the generation they were observed for
+	SetReadyCondition(&issuerStatus, 2, metav1.ConditionTrue, "reason4", "message4")
+	assert.Equal(t, int64(2), GetReadyCondition(&issuerStatus).ObservedGeneration)
+	assert.Equal(t, int64(1), GetCondition(&issuerStatus, cfsslissuerapi.IssuerConditionCAExpiringSoon).ObservedGeneration)
+}
+
+func TestConvertConditions(t *testing.T) {
+	now := metav1.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	before := metav1.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	issuerStatus := cfsslissuerapi.IssuerStatus{
+		Conditions: []metav1.Condition{
+			{Type: cfsslissuerapi.IssuerConditionReady},
+			{
+				Type:               cfsslissuerapi.IssuerConditionCAExpiringSoon,
+				Status:             metav1.ConditionFalse,
+				Reason:             "reason1",
+				LastTransitionTime: before,
+			},
+		},
+	}
+
+	ConvertConditions(&issuerStatus, now)
+	assert.Equal(t, []metav1.Condition{
+		{
+			Type:               cfsslissuerapi.IssuerConditionReady,
+			Status:             metav1.ConditionUnknown,
+			Reason:             string(cfsslissuerapi.IssuerReasonUnspecified),
+			LastTransitionTime: now,
+		},
+		{
+			Type:               cfsslissuerapi.IssuerConditionCAExpiringSoon,
+			Status:             metav1.ConditionFalse,
+			Reason:             "reason1",
+			LastTransitionTime: before,
+		},
+	}, issuerStatus.Conditions)
+}
@@ -136,6 +136,7 @@ func main() {
 		Scheme:                   mgr.GetScheme(),
 		ClusterResourceNamespace: clusterResourceNamespace,
 		HealthCheckerBuilder:     signer.NewCfsslHealthChecker,
+		Clock:                    clock.RealClock{},
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Issuer")
 		os.Exit(1)
@@ -146,6 +147,7 @@ func main() {
 		Scheme:                   mgr.GetScheme(),
 		ClusterResourceNamespace: clusterResourceNamespace,
 		HealthCheckerBuilder:     signer.NewCfsslHealthChecker,
+		Clock:                    clock.RealClock{},
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ClusterIssuer")
 		os.Exit(1)